@@ -0,0 +1,86 @@
+package fake
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	DefaultVisibilityTimeout = 30 * time.Second
+	DeduplicationWindow      = 5 * time.Minute
+	AccountID                = "000000000000"
+	Region                   = "us-east-1"
+	pollInterval             = 10 * time.Millisecond
+)
+
+// Broker guarda el estado compartido de las colas y tópicos en memoria. SQS y SNS exponen
+// ese estado a través de las interfaces sqs.Service y sns.Service.
+type Broker struct {
+	mu      sync.Mutex
+	offset  time.Duration
+	seq     int
+	queues  map[string]*queue
+	topics  map[string]*topic
+	dedupes map[string]time.Time
+}
+
+// SubscriptionOptions configura una suscripción de un tópico a una cola.
+// FilterPolicy usa la sintaxis de SNS sobre los atributos del mensaje.
+type SubscriptionOptions struct {
+	RawDelivery  bool
+	FilterPolicy string
+}
+
+type SQS struct {
+	broker *Broker
+}
+
+type SNS struct {
+	broker *Broker
+}
+
+type queue struct {
+	url      string
+	fifo     bool
+	messages []*message
+}
+
+type message struct {
+	id            string
+	body          string
+	attributes    map[string]types.MessageAttributeValue
+	groupID       string
+	receiveCount  int
+	receiptHandle string
+	sentAt        time.Time
+	visibleAt     time.Time
+}
+
+type topic struct {
+	arn           string
+	fifo          bool
+	subscriptions []subscription
+}
+
+type subscription struct {
+	queueURL string
+	raw      bool
+	filter   map[string]interface{}
+}
+
+type snsNotification struct {
+	Type              string                          `json:"Type"`
+	MessageID         string                          `json:"MessageId"`
+	TopicArn          string                          `json:"TopicArn"`
+	Subject           string                          `json:"Subject,omitempty"`
+	Message           string                          `json:"Message"`
+	Timestamp         string                          `json:"Timestamp"`
+	MessageAttributes map[string]snsNotificationValue `json:"MessageAttributes,omitempty"`
+}
+
+type snsNotificationValue struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
@@ -0,0 +1,137 @@
+package fake
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+// matchesFilter evalúa una filter policy de SNS sobre los atributos del mensaje. Soporta valores
+// exactos (string y número), prefix, anything-but, exists y numeric.
+func matchesFilter(policy map[string]interface{}, attributes map[string]snstypes.MessageAttributeValue) bool {
+	for key, rule := range policy {
+		conditions, ok := rule.([]interface{})
+		if !ok {
+			return false
+		}
+		attr, exists := attributes[key]
+		values := attributeValues(attr)
+		matched := false
+		for _, c := range conditions {
+			if matchCondition(c, values, exists) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func matchCondition(condition interface{}, values []string, exists bool) bool {
+	switch c := condition.(type) {
+	case string:
+		return exists && contains(values, c)
+	case float64:
+		return exists && anyNumber(values, func(n float64) bool { return n == c })
+	case map[string]interface{}:
+		if e, ok := c["exists"].(bool); ok {
+			return e == exists
+		}
+		if !exists {
+			return false
+		}
+		if prefix, ok := c["prefix"].(string); ok {
+			for _, v := range values {
+				if strings.HasPrefix(v, prefix) {
+					return true
+				}
+			}
+			return false
+		}
+		if excluded, ok := c["anything-but"]; ok {
+			list, isList := excluded.([]interface{})
+			if !isList {
+				list = []interface{}{excluded}
+			}
+			for _, e := range list {
+				if matchCondition(e, values, exists) {
+					return false
+				}
+			}
+			return true
+		}
+		if numeric, ok := c["numeric"].([]interface{}); ok {
+			return anyNumber(values, func(n float64) bool { return matchNumeric(numeric, n) })
+		}
+	}
+	return false
+}
+
+func matchNumeric(rule []interface{}, n float64) bool {
+	for i := 0; i+1 < len(rule); i += 2 {
+		op, _ := rule[i].(string)
+		limit, _ := rule[i+1].(float64)
+		ok := false
+		switch op {
+		case "=":
+			ok = n == limit
+		case ">":
+			ok = n > limit
+		case ">=":
+			ok = n >= limit
+		case "<":
+			ok = n < limit
+		case "<=":
+			ok = n <= limit
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// attributeValues devuelve los valores del atributo; los String.Array se expanden.
+func attributeValues(attr snstypes.MessageAttributeValue) []string {
+	value := aws.ToString(attr.StringValue)
+	if aws.ToString(attr.DataType) == "String.Array" {
+		var list []interface{}
+		if err := json.Unmarshal([]byte(value), &list); err == nil {
+			values := make([]string, 0, len(list))
+			for _, v := range list {
+				switch x := v.(type) {
+				case string:
+					values = append(values, x)
+				case float64:
+					values = append(values, strconv.FormatFloat(x, 'f', -1, 64))
+				}
+			}
+			return values
+		}
+	}
+	return []string{value}
+}
+
+func anyNumber(values []string, match func(float64) bool) bool {
+	for _, v := range values {
+		if n, err := strconv.ParseFloat(v, 64); err == nil && match(n) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/uala-challenge/simple-toolkit/pkg/client/sns"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/sqs"
+)
+
+var (
+	_ sqs.Service = (*SQS)(nil)
+	_ sns.Service = (*SNS)(nil)
+)
+
+func NewBroker() *Broker {
+	return &Broker{
+		queues:  make(map[string]*queue),
+		topics:  make(map[string]*topic),
+		dedupes: make(map[string]time.Time),
+	}
+}
+
+// SQS devuelve un cliente listo para usar donde se espera un *sqs.Sqs.
+func (b *Broker) SQS() *sqs.Sqs {
+	return &sqs.Sqs{Cliente: &SQS{broker: b}}
+}
+
+// SNS devuelve un cliente listo para usar donde se espera un *sns.Sns.
+func (b *Broker) SNS() *sns.Sns {
+	return &sns.Sns{Cliente: &SNS{broker: b}}
+}
+
+// CreateQueue crea la cola si no existe y devuelve su URL. Los nombres terminados en .fifo son FIFO.
+func (b *Broker) CreateQueue(name string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	url := fmt.Sprintf("https://sqs.%s.amazonaws.com/%s/%s", Region, AccountID, name)
+	if _, ok := b.queues[url]; !ok {
+		b.queues[url] = &queue{url: url, fifo: strings.HasSuffix(name, ".fifo")}
+	}
+	return url
+}
+
+// CreateTopic crea el tópico si no existe y devuelve su ARN.
+func (b *Broker) CreateTopic(name string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	arn := fmt.Sprintf("arn:aws:sns:%s:%s:%s", Region, AccountID, name)
+	if _, ok := b.topics[arn]; !ok {
+		b.topics[arn] = &topic{arn: arn, fifo: strings.HasSuffix(name, ".fifo")}
+	}
+	return arn
+}
+
+// Subscribe suscribe una cola a un tópico; ambos deben existir.
+func (b *Broker) Subscribe(topicArn, queueURL string, opts SubscriptionOptions) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok := b.topics[topicArn]
+	if !ok {
+		return fmt.Errorf("topic %s does not exist", topicArn)
+	}
+	if _, ok := b.queues[queueURL]; !ok {
+		return fmt.Errorf("queue %s does not exist", queueURL)
+	}
+	sub := subscription{queueURL: queueURL, raw: opts.RawDelivery}
+	if opts.FilterPolicy != "" {
+		if err := json.Unmarshal([]byte(opts.FilterPolicy), &sub.filter); err != nil {
+			return fmt.Errorf("invalid filter policy: %w", err)
+		}
+	}
+	t.subscriptions = append(t.subscriptions, sub)
+	return nil
+}
+
+// Advance adelanta el reloj del broker, p.ej. para que venza la visibilidad de un mensaje sin esperar.
+func (b *Broker) Advance(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.offset += d
+}
+
+// Len devuelve la cantidad de mensajes de la cola, visibles o en vuelo.
+func (b *Broker) Len(queueURL string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q, ok := b.queues[queueURL]
+	if !ok {
+		return 0
+	}
+	return len(q.messages)
+}
+
+// Bodies devuelve el cuerpo de los mensajes de la cola en orden de llegada, sin recibirlos.
+func (b *Broker) Bodies(queueURL string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q, ok := b.queues[queueURL]
+	if !ok {
+		return nil
+	}
+	bodies := make([]string, 0, len(q.messages))
+	for _, m := range q.messages {
+		bodies = append(bodies, m.body)
+	}
+	return bodies
+}
+
+func (b *Broker) now() time.Time {
+	return time.Now().Add(b.offset)
+}
+
+func (b *Broker) nextID(prefix string) string {
+	b.seq++
+	return fmt.Sprintf("%s-%d", prefix, b.seq)
+}
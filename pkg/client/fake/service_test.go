@@ -0,0 +1,195 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssns "github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/sns"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/sqs"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+)
+
+type transfer struct {
+	ID     string `json:"id"`
+	Amount int    `json:"amount"`
+}
+
+func receive(t *testing.T, client *sqs.Sqs, url string) []types.Message {
+	out, err := client.Cliente.ReceiveMessage(context.Background(), &awssqs.ReceiveMessageInput{
+		QueueUrl:              aws.String(url),
+		MaxNumberOfMessages:   10,
+		MessageAttributeNames: []string{"All"},
+	})
+	assert.NoError(t, err)
+	return out.Messages
+}
+
+func TestQueueVisibilityAndDelete(t *testing.T) {
+	broker := NewBroker()
+	url := broker.CreateQueue("transfers")
+	client := broker.SQS()
+	ctx := context.Background()
+
+	_, err := client.Cliente.SendMessage(ctx, &awssqs.SendMessageInput{QueueUrl: aws.String(url), MessageBody: aws.String("hola")})
+	assert.NoError(t, err)
+
+	first := receive(t, client, url)
+	assert.Len(t, first, 1)
+	assert.Equal(t, 1, sqs.ReceiveCount(first[0]))
+	assert.Empty(t, receive(t, client, url))
+
+	broker.Advance(DefaultVisibilityTimeout + time.Second)
+	second := receive(t, client, url)
+	assert.Len(t, second, 1)
+	assert.Equal(t, 2, sqs.ReceiveCount(second[0]))
+
+	_, err = client.Cliente.DeleteMessage(ctx, &awssqs.DeleteMessageInput{QueueUrl: aws.String(url), ReceiptHandle: first[0].ReceiptHandle})
+	var invalid *types.ReceiptHandleIsInvalid
+	assert.ErrorAs(t, err, &invalid)
+
+	_, err = client.Cliente.DeleteMessage(ctx, &awssqs.DeleteMessageInput{QueueUrl: aws.String(url), ReceiptHandle: second[0].ReceiptHandle})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, broker.Len(url))
+}
+
+func TestFIFOQueueGroupsAndDeduplication(t *testing.T) {
+	broker := NewBroker()
+	url := broker.CreateQueue("transfers.fifo")
+	client := broker.SQS()
+	ctx := context.Background()
+
+	for _, m := range []struct{ body, group, dedup string }{
+		{"a1", "a", "1"}, {"a2", "a", "2"}, {"a2", "a", "2"}, {"b1", "b", "3"},
+	} {
+		_, err := client.Cliente.SendMessage(ctx, &awssqs.SendMessageInput{
+			QueueUrl:               aws.String(url),
+			MessageBody:            aws.String(m.body),
+			MessageGroupId:         aws.String(m.group),
+			MessageDeduplicationId: aws.String(m.dedup),
+		})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"a1", "a2", "b1"}, broker.Bodies(url))
+
+	out, err := client.Cliente.ReceiveMessage(ctx, &awssqs.ReceiveMessageInput{QueueUrl: aws.String(url)})
+	assert.NoError(t, err)
+	assert.Equal(t, "a1", aws.ToString(out.Messages[0].Body))
+
+	next := receive(t, client, url)
+	assert.Len(t, next, 1)
+	assert.Equal(t, "b1", aws.ToString(next[0].Body))
+
+	_, err = client.Cliente.SendMessage(ctx, &awssqs.SendMessageInput{QueueUrl: aws.String(url), MessageBody: aws.String("x")})
+	assert.Error(t, err)
+}
+
+func TestTopicFanOutWithFilterPolicy(t *testing.T) {
+	broker := NewBroker()
+	topicArn := broker.CreateTopic("transfers")
+	all := broker.CreateQueue("audit")
+	created := broker.CreateQueue("notifications")
+	assert.NoError(t, broker.Subscribe(topicArn, all, SubscriptionOptions{}))
+	assert.NoError(t, broker.Subscribe(topicArn, created, SubscriptionOptions{
+		RawDelivery:  true,
+		FilterPolicy: `{"event_type": ["transfer_created"], "amount": [{"numeric": [">", 100]}]}`,
+	}))
+
+	publish := func(eventType, amount string) {
+		_, err := broker.SNS().Cliente.Publish(context.Background(), &awssns.PublishInput{
+			TopicArn: aws.String(topicArn),
+			Message:  aws.String(`{"id":"t-1","amount":` + amount + `}`),
+			MessageAttributes: map[string]snstypes.MessageAttributeValue{
+				"event_type": {DataType: aws.String("String"), StringValue: aws.String(eventType)},
+				"amount":     {DataType: aws.String("Number"), StringValue: aws.String(amount)},
+			},
+		})
+		assert.NoError(t, err)
+	}
+	publish("transfer_created", "500")
+	publish("transfer_created", "50")
+	publish("transfer_failed", "500")
+
+	assert.Equal(t, 3, broker.Len(all))
+	assert.Equal(t, []string{`{"id":"t-1","amount":500}`}, broker.Bodies(created))
+
+	messages := receive(t, broker.SQS(), created)
+	assert.Equal(t, "transfer_created", aws.ToString(messages[0].MessageAttributes["event_type"].StringValue))
+}
+
+func TestProducerTopicQueueConsumer(t *testing.T) {
+	broker := NewBroker()
+	topicArn := broker.CreateTopic("transfers")
+	url := broker.CreateQueue("transfers-worker")
+	assert.NoError(t, broker.Subscribe(topicArn, url, SubscriptionOptions{}))
+
+	_, err := broker.SNS().Cliente.Publish(context.Background(), &awssns.PublishInput{
+		TopicArn: aws.String(topicArn),
+		Message:  aws.String(`{"id":"t-9","amount":10}`),
+	})
+	assert.NoError(t, err)
+
+	received := make(chan transfer, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	consumer := sqs.NewConsumer(sqs.Dependencies{Client: broker.SQS(), Log: log.NewService(log.Config{}, logrus.New())},
+		sqs.ConsumerConfig{QueueURL: url},
+		func(ctx context.Context, msg types.Message) error {
+			d, err := sqs.Decode[transfer](msg)
+			if err != nil {
+				return err
+			}
+			received <- d.Payload
+			cancel()
+			return nil
+		})
+
+	assert.NoError(t, consumer.Start(ctx))
+	assert.Equal(t, transfer{ID: "t-9", Amount: 10}, <-received)
+	assert.Equal(t, 0, broker.Len(url))
+}
+
+func TestMatchesFilter(t *testing.T) {
+	attributes := map[string]snstypes.MessageAttributeValue{
+		"currency": {DataType: aws.String("String"), StringValue: aws.String("ARS")},
+		"channels": {DataType: aws.String("String.Array"), StringValue: aws.String(`["push","email"]`)},
+	}
+	cases := map[string]bool{
+		`{"currency": [{"prefix": "AR"}]}`:            true,
+		`{"currency": [{"anything-but": ["ARS"]}]}`:   false,
+		`{"currency": [{"anything-but": "USD"}]}`:     true,
+		`{"channels": ["sms", "email"]}`:              true,
+		`{"missing": [{"exists": false}]}`:            true,
+		`{"missing": [{"exists": true}]}`:             false,
+		`{"currency": ["USD"], "channels": ["push"]}`: false,
+	}
+	for policy, expected := range cases {
+		var filter map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(policy), &filter))
+		assert.Equal(t, expected, matchesFilter(filter, attributes), policy)
+	}
+}
+
+func TestPublisherBatchFanOut(t *testing.T) {
+	broker := NewBroker()
+	topicArn := broker.CreateTopic("transfers")
+	url := broker.CreateQueue("transfers-worker")
+	assert.NoError(t, broker.Subscribe(topicArn, url, SubscriptionOptions{RawDelivery: true}))
+
+	publisher := sns.NewPublisher(sns.Dependencies{Client: broker.SNS(), Log: log.NewService(log.Config{}, logrus.New())},
+		sns.Config{Topics: map[string]string{"transfers": topicArn}})
+	err := publisher.PublishBatch(context.Background(), "transfers", []sns.Event{
+		{Payload: transfer{ID: "t-1"}, EventType: "transfer_created"},
+		{Payload: transfer{ID: "t-2"}, EventType: "transfer_created"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`{"id":"t-1","amount":0}`, `{"id":"t-2","amount":0}`}, broker.Bodies(url))
+}
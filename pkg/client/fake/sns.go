@@ -0,0 +1,135 @@
+package fake
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go"
+)
+
+// Publish entrega el mensaje a cada cola suscrita cuyo filtro acepte los atributos.
+func (s *SNS) Publish(_ context.Context, in *sns.PublishInput, _ ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	id, err := s.broker.publish(in)
+	if err != nil {
+		return nil, err
+	}
+	return &sns.PublishOutput{MessageId: aws.String(id)}, nil
+}
+
+// PublishBatch publica cada entrada por separado; los errores de validación se informan en Failed.
+func (s *SNS) PublishBatch(_ context.Context, in *sns.PublishBatchInput, _ ...func(*sns.Options)) (*sns.PublishBatchOutput, error) {
+	if len(in.PublishBatchRequestEntries) > maxBatchEntries {
+		return nil, &snstypes.TooManyEntriesInBatchRequestException{Message: aws.String("batch has more than 10 entries")}
+	}
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	if _, ok := s.broker.topics[aws.ToString(in.TopicArn)]; !ok {
+		return nil, &snstypes.NotFoundException{Message: aws.String(fmt.Sprintf("topic %s does not exist", aws.ToString(in.TopicArn)))}
+	}
+
+	out := &sns.PublishBatchOutput{}
+	for _, e := range in.PublishBatchRequestEntries {
+		id, err := s.broker.publish(&sns.PublishInput{
+			TopicArn:               in.TopicArn,
+			Message:                e.Message,
+			Subject:                e.Subject,
+			MessageAttributes:      e.MessageAttributes,
+			MessageGroupId:         e.MessageGroupId,
+			MessageDeduplicationId: e.MessageDeduplicationId,
+		})
+		if err != nil {
+			out.Failed = append(out.Failed, snstypes.BatchResultErrorEntry{
+				Id:          e.Id,
+				Code:        aws.String(errorCode(err)),
+				Message:     aws.String(err.Error()),
+				SenderFault: true,
+			})
+			continue
+		}
+		out.Successful = append(out.Successful, snstypes.PublishBatchResultEntry{Id: e.Id, MessageId: aws.String(id)})
+	}
+	return out, nil
+}
+
+// publish debe llamarse con el lock del broker tomado.
+func (b *Broker) publish(in *sns.PublishInput) (string, error) {
+	arn := aws.ToString(in.TopicArn)
+	if arn == "" {
+		arn = aws.ToString(in.TargetArn)
+	}
+	t, ok := b.topics[arn]
+	if !ok {
+		return "", &snstypes.NotFoundException{Message: aws.String(fmt.Sprintf("topic %s does not exist", arn))}
+	}
+	body := aws.ToString(in.Message)
+	groupID := aws.ToString(in.MessageGroupId)
+	dedupID := aws.ToString(in.MessageDeduplicationId)
+	if t.fifo {
+		if groupID == "" {
+			return "", &smithy.GenericAPIError{Code: "InvalidParameter", Message: "fifo topics require MessageGroupId"}
+		}
+		if dedupID == "" {
+			hash := sha256.Sum256([]byte(body))
+			dedupID = hex.EncodeToString(hash[:])
+		}
+	}
+
+	id := b.nextID("sns")
+	for _, sub := range t.subscriptions {
+		if sub.filter != nil && !matchesFilter(sub.filter, in.MessageAttributes) {
+			continue
+		}
+		delivered, attributes := body, sqsAttributes(in.MessageAttributes)
+		if !sub.raw {
+			envelope, err := json.Marshal(snsNotification{
+				Type:              "Notification",
+				MessageID:         id,
+				TopicArn:          arn,
+				Subject:           aws.ToString(in.Subject),
+				Message:           body,
+				Timestamp:         b.now().UTC().Format(time.RFC3339Nano),
+				MessageAttributes: notificationAttributes(in.MessageAttributes),
+			})
+			if err != nil {
+				return "", err
+			}
+			delivered, attributes = string(envelope), nil
+		}
+		if _, err := b.enqueue(sub.queueURL, delivered, attributes, groupID, dedupID, 0); err != nil {
+			return "", err
+		}
+	}
+	return id, nil
+}
+
+func sqsAttributes(attributes map[string]snstypes.MessageAttributeValue) map[string]sqstypes.MessageAttributeValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	out := make(map[string]sqstypes.MessageAttributeValue, len(attributes))
+	for k, v := range attributes {
+		out[k] = sqstypes.MessageAttributeValue{DataType: v.DataType, StringValue: v.StringValue, BinaryValue: v.BinaryValue}
+	}
+	return out
+}
+
+func notificationAttributes(attributes map[string]snstypes.MessageAttributeValue) map[string]snsNotificationValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	out := make(map[string]snsNotificationValue, len(attributes))
+	for k, v := range attributes {
+		out[k] = snsNotificationValue{Type: aws.ToString(v.DataType), Value: aws.ToString(v.StringValue)}
+	}
+	return out
+}
@@ -0,0 +1,230 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go"
+)
+
+const maxBatchEntries = 10
+
+func (s *SQS) SendMessage(_ context.Context, in *sqs.SendMessageInput, _ ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	id, err := s.broker.enqueue(aws.ToString(in.QueueUrl), aws.ToString(in.MessageBody), in.MessageAttributes,
+		aws.ToString(in.MessageGroupId), aws.ToString(in.MessageDeduplicationId), in.DelaySeconds)
+	if err != nil {
+		return nil, err
+	}
+	return &sqs.SendMessageOutput{MessageId: aws.String(id)}, nil
+}
+
+func (s *SQS) SendMessageBatch(_ context.Context, in *sqs.SendMessageBatchInput, _ ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error) {
+	if len(in.Entries) > maxBatchEntries {
+		return nil, &types.TooManyEntriesInBatchRequest{Message: aws.String("batch has more than 10 entries")}
+	}
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	out := &sqs.SendMessageBatchOutput{}
+	for _, e := range in.Entries {
+		id, err := s.broker.enqueue(aws.ToString(in.QueueUrl), aws.ToString(e.MessageBody), e.MessageAttributes,
+			aws.ToString(e.MessageGroupId), aws.ToString(e.MessageDeduplicationId), e.DelaySeconds)
+		var notFound *types.QueueDoesNotExist
+		if errors.As(err, &notFound) {
+			return nil, err
+		}
+		if err != nil {
+			out.Failed = append(out.Failed, types.BatchResultErrorEntry{
+				Id:          e.Id,
+				Code:        aws.String(errorCode(err)),
+				Message:     aws.String(err.Error()),
+				SenderFault: true,
+			})
+			continue
+		}
+		out.Successful = append(out.Successful, types.SendMessageBatchResultEntry{Id: e.Id, MessageId: aws.String(id)})
+	}
+	return out, nil
+}
+
+// ReceiveMessage respeta WaitTimeSeconds: espera hasta que haya mensajes visibles, venza el plazo o se cancele ctx.
+func (s *SQS) ReceiveMessage(ctx context.Context, in *sqs.ReceiveMessageInput, _ ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	deadline := time.Now().Add(time.Duration(in.WaitTimeSeconds) * time.Second)
+	for {
+		messages, err := s.broker.receive(in)
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) > 0 || !time.Now().Before(deadline) {
+			return &sqs.ReceiveMessageOutput{Messages: messages}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (s *SQS) DeleteMessage(_ context.Context, in *sqs.DeleteMessageInput, _ ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error) {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	q, i, err := s.broker.find(aws.ToString(in.QueueUrl), aws.ToString(in.ReceiptHandle))
+	if err != nil {
+		return nil, err
+	}
+	q.messages = append(q.messages[:i], q.messages[i+1:]...)
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+func (s *SQS) ChangeMessageVisibility(_ context.Context, in *sqs.ChangeMessageVisibilityInput, _ ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error) {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	q, i, err := s.broker.find(aws.ToString(in.QueueUrl), aws.ToString(in.ReceiptHandle))
+	if err != nil {
+		return nil, err
+	}
+	q.messages[i].visibleAt = s.broker.now().Add(time.Duration(in.VisibilityTimeout) * time.Second)
+	return &sqs.ChangeMessageVisibilityOutput{}, nil
+}
+
+// enqueue debe llamarse con el lock del broker tomado.
+func (b *Broker) enqueue(url, body string, attributes map[string]types.MessageAttributeValue, groupID, dedupID string, delay int32) (string, error) {
+	q, ok := b.queues[url]
+	if !ok {
+		return "", &types.QueueDoesNotExist{Message: aws.String(fmt.Sprintf("queue %s does not exist", url))}
+	}
+	now := b.now()
+	id := b.nextID("msg")
+	if q.fifo {
+		if groupID == "" {
+			return "", &smithy.GenericAPIError{Code: "MissingParameter", Message: "fifo queues require MessageGroupId"}
+		}
+		if dedupID == "" {
+			return "", &smithy.GenericAPIError{Code: "InvalidParameterValue", Message: "fifo queues require MessageDeduplicationId"}
+		}
+		key := url + "|" + dedupID
+		if sentAt, ok := b.dedupes[key]; ok && now.Sub(sentAt) < DeduplicationWindow {
+			return id, nil
+		}
+		b.dedupes[key] = now
+		delay = 0
+	}
+	q.messages = append(q.messages, &message{
+		id:         id,
+		body:       body,
+		attributes: attributes,
+		groupID:    groupID,
+		sentAt:     now,
+		visibleAt:  now.Add(time.Duration(delay) * time.Second),
+	})
+	return id, nil
+}
+
+func (b *Broker) receive(in *sqs.ReceiveMessageInput) ([]types.Message, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q, ok := b.queues[aws.ToString(in.QueueUrl)]
+	if !ok {
+		return nil, &types.QueueDoesNotExist{Message: aws.String(fmt.Sprintf("queue %s does not exist", aws.ToString(in.QueueUrl)))}
+	}
+	limit := int(in.MaxNumberOfMessages)
+	if limit <= 0 {
+		limit = 1
+	}
+	visibility := DefaultVisibilityTimeout
+	if in.VisibilityTimeout > 0 {
+		visibility = time.Duration(in.VisibilityTimeout) * time.Second
+	}
+
+	now := b.now()
+	blocked := make(map[string]bool)
+	if q.fifo {
+		for _, m := range q.messages {
+			if m.receiveCount > 0 && m.visibleAt.After(now) {
+				blocked[m.groupID] = true
+			}
+		}
+	}
+
+	var out []types.Message
+	for _, m := range q.messages {
+		if len(out) == limit {
+			break
+		}
+		if q.fifo && blocked[m.groupID] {
+			continue
+		}
+		if m.visibleAt.After(now) {
+			continue
+		}
+		m.receiveCount++
+		m.receiptHandle = b.nextID(m.id)
+		m.visibleAt = now.Add(visibility)
+		out = append(out, m.toSQS(q.fifo, in.MessageAttributeNames))
+	}
+	return out, nil
+}
+
+func (b *Broker) find(url, receiptHandle string) (*queue, int, error) {
+	q, ok := b.queues[url]
+	if !ok {
+		return nil, 0, &types.QueueDoesNotExist{Message: aws.String(fmt.Sprintf("queue %s does not exist", url))}
+	}
+	for i, m := range q.messages {
+		if m.receiptHandle != "" && m.receiptHandle == receiptHandle {
+			return q, i, nil
+		}
+	}
+	return nil, 0, &types.ReceiptHandleIsInvalid{Message: aws.String("receipt handle is invalid or expired")}
+}
+
+func (m *message) toSQS(fifo bool, attributeNames []string) types.Message {
+	attributes := map[string]string{
+		string(types.MessageSystemAttributeNameApproximateReceiveCount): strconv.Itoa(m.receiveCount),
+		string(types.MessageSystemAttributeNameSentTimestamp):           strconv.FormatInt(m.sentAt.UnixMilli(), 10),
+	}
+	if fifo {
+		attributes[string(types.MessageSystemAttributeNameMessageGroupId)] = m.groupID
+	}
+	return types.Message{
+		MessageId:         aws.String(m.id),
+		ReceiptHandle:     aws.String(m.receiptHandle),
+		Body:              aws.String(m.body),
+		Attributes:        attributes,
+		MessageAttributes: selectAttributes(m.attributes, attributeNames),
+	}
+}
+
+// selectAttributes devuelve solo los atributos pedidos, como SQS; "All" o ".*" los devuelve todos.
+func selectAttributes(attributes map[string]types.MessageAttributeValue, names []string) map[string]types.MessageAttributeValue {
+	if len(attributes) == 0 || len(names) == 0 {
+		return nil
+	}
+	selected := make(map[string]types.MessageAttributeValue)
+	for _, name := range names {
+		if name == "All" || name == ".*" {
+			return attributes
+		}
+		if v, ok := attributes[name]; ok {
+			selected[name] = v
+		}
+	}
+	return selected
+}
+
+func errorCode(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	return "InternalError"
+}
@@ -0,0 +1,240 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// WithAuthProvider registra un AuthProvider propio; tiene prioridad sobre Config.Auth.
+func WithAuthProvider(p AuthProvider) Option {
+	return func(o *clientOptions) {
+		o.auth = p
+	}
+}
+
+// WithAWSConfig entrega las credenciales y la región usadas por la firma SigV4.
+func WithAWSConfig(cfg aws.Config) Option {
+	return func(o *clientOptions) {
+		o.awsConfig = &cfg
+	}
+}
+
+func newAuthProvider(c AuthConfig, awsCfg *aws.Config) AuthProvider {
+	switch strings.ToLower(c.Type) {
+	case "":
+		return nil
+	case AuthBearer:
+		token := c.Token
+		if c.TokenEnv != "" {
+			token = os.Getenv(c.TokenEnv)
+		}
+		return &bearerProvider{token: token}
+	case AuthBasic:
+		return &basicProvider{username: c.Username, password: c.Password}
+	case AuthOAuth2:
+		return &oauth2Provider{
+			cfg:        c,
+			httpClient: &http.Client{Timeout: 10 * time.Second},
+		}
+	case AuthHMAC:
+		header := c.SignatureHeader
+		if header == "" {
+			header = DefaultSignatureHeader
+		}
+		return &hmacProvider{keyID: c.KeyID, secret: []byte(c.Secret), header: header}
+	case AuthSigV4:
+		return newSigV4Provider(c, awsCfg)
+	default:
+		return &invalidProvider{err: fmt.Errorf("unsupported auth type %q", c.Type)}
+	}
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if err := t.provider.Authenticate(req); err != nil {
+		return nil, fmt.Errorf("error authenticating request: %w", err)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		if i, ok := t.provider.(tokenInvalidator); ok {
+			i.invalidate(req)
+		}
+	}
+	return resp, err
+}
+
+func (p *bearerProvider) Authenticate(req *http.Request) error {
+	if p.token == "" {
+		return fmt.Errorf("bearer token is empty")
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	return nil
+}
+
+func (p *basicProvider) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(p.username, p.password)
+	return nil
+}
+
+func (p *invalidProvider) Authenticate(*http.Request) error {
+	return p.err
+}
+
+func (p *oauth2Provider) Authenticate(req *http.Request) error {
+	token, err := p.accessToken(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (p *oauth2Provider) accessToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && time.Now().Before(p.refreshAt) {
+		return p.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("client_secret", p.cfg.ClientSecret)
+	if len(p.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", contentTypeJSON)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error requesting oauth2 token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("error requesting oauth2 token: HTTP %d", resp.StatusCode)
+	}
+
+	var tr tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return "", fmt.Errorf("error decoding oauth2 token: %w", err)
+	}
+	if tr.AccessToken == "" {
+		return "", fmt.Errorf("oauth2 token response without access_token")
+	}
+	lifetime := time.Duration(tr.ExpiresIn) * time.Second
+	if lifetime <= 0 {
+		lifetime = DefaultOAuth2TokenLifetime
+	}
+	p.token = tr.AccessToken
+	p.refreshAt = time.Now().Add(lifetime - min(OAuth2RefreshWindow, lifetime/2))
+	return p.token, nil
+}
+
+// invalidate descarta el token cacheado si es el que usó la solicitud rechazada,
+// para no tirar uno que otra solicitud ya renovó.
+func (p *oauth2Provider) invalidate(req *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && req.Header.Get("Authorization") == "Bearer "+p.token {
+		p.token = ""
+	}
+}
+
+// Authenticate firma METHOD\nPATH?QUERY\nTIMESTAMP\nSHA256(BODY) con HMAC-SHA256.
+func (p *hmacProvider) Authenticate(req *http.Request) error {
+	body, err := requestBody(req)
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	bodyHash := sha256.Sum256(body)
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.RequestURI(),
+		timestamp,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(canonical))
+
+	req.Header.Set(TimestampHeader, timestamp)
+	if p.keyID != "" {
+		req.Header.Set(KeyIDHeader, p.keyID)
+	}
+	req.Header.Set(p.header, hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+func newSigV4Provider(c AuthConfig, awsCfg *aws.Config) AuthProvider {
+	if awsCfg == nil || awsCfg.Credentials == nil {
+		return &invalidProvider{err: fmt.Errorf("sigv4 auth requires aws credentials")}
+	}
+	region := c.Region
+	if region == "" {
+		region = awsCfg.Region
+	}
+	if c.Service == "" || region == "" {
+		return &invalidProvider{err: fmt.Errorf("sigv4 auth requires service and region")}
+	}
+	return &sigV4Provider{
+		signer:      v4.NewSigner(),
+		credentials: awsCfg.Credentials,
+		service:     c.Service,
+		region:      region,
+	}
+}
+
+// Authenticate firma la solicitud con SigV4 usando el hash SHA256 del cuerpo.
+func (p *sigV4Provider) Authenticate(req *http.Request) error {
+	body, err := requestBody(req)
+	if err != nil {
+		return err
+	}
+	creds, err := p.credentials.Retrieve(req.Context())
+	if err != nil {
+		return fmt.Errorf("error retrieving aws credentials: %w", err)
+	}
+	bodyHash := sha256.Sum256(body)
+	return p.signer.SignHTTP(req.Context(), creds, req, hex.EncodeToString(bodyHash[:]), p.service, p.region, time.Now())
+}
+
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,215 @@
+package rest
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearerAuthFromEnv(t *testing.T) {
+	t.Setenv("PARTNER_TOKEN", "env-token")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer env-token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithRetry
+	cfg.Auth = AuthConfig{Type: AuthBearer, TokenEnv: "PARTNER_TOKEN"}
+	client := NewClient(cfg, logrus.New())
+
+	_, err := client.Get(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+}
+
+func TestBasicAuth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "pass", pass)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithRetry
+	cfg.Auth = AuthConfig{Type: AuthBasic, Username: "user", Password: "pass"}
+	client := NewClient(cfg, logrus.New())
+
+	_, err := client.Get(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+}
+
+func TestOAuth2ClientCredentialsCachesToken(t *testing.T) {
+	tokenCalls := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenCalls++
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "id", r.PostForm.Get("client_id"))
+		assert.Equal(t, "read write", r.PostForm.Get("scope"))
+		_, _ = w.Write([]byte(`{"access_token": "oauth-token", "expires_in": 3600}`))
+	}))
+	defer tokenServer.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer oauth-token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithRetry
+	cfg.Auth = AuthConfig{
+		Type:         AuthOAuth2,
+		TokenURL:     tokenServer.URL,
+		ClientID:     "id",
+		ClientSecret: "secret",
+		Scopes:       []string{"read", "write"},
+	}
+	client := NewClient(cfg, logrus.New())
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Get(context.Background(), ts.URL, nil)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, tokenCalls)
+}
+
+func TestOAuth2DefaultLifetimeAndInvalidateOn401(t *testing.T) {
+	tokenCalls := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenCalls++
+		_, _ = w.Write([]byte(`{"access_token": "oauth-token"}`))
+	}))
+	defer tokenServer.Close()
+
+	unauthorized := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unauthorized {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithRetry
+	cfg.Auth = AuthConfig{Type: AuthOAuth2, TokenURL: tokenServer.URL, ClientID: "id", ClientSecret: "secret"}
+	client := NewClient(cfg, logrus.New())
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Get(context.Background(), ts.URL, nil)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, tokenCalls)
+
+	unauthorized = true
+	_, err := client.Get(context.Background(), ts.URL, nil)
+	assert.Error(t, err)
+
+	unauthorized = false
+	_, err = client.Get(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, tokenCalls)
+}
+
+func TestHMACSignature(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodyHash := sha256.Sum256(body)
+		canonical := strings.Join([]string{
+			r.Method,
+			r.URL.RequestURI(),
+			r.Header.Get(TimestampHeader),
+			hex.EncodeToString(bodyHash[:]),
+		}, "\n")
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(canonical))
+
+		assert.Equal(t, "key-1", r.Header.Get(KeyIDHeader))
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get(DefaultSignatureHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithRetry
+	cfg.Auth = AuthConfig{Type: AuthHMAC, KeyID: "key-1", Secret: "secret"}
+	client := NewClient(cfg, logrus.New())
+
+	_, err := client.Post(context.Background(), ts.URL+"/sign?x=1", map[string]string{"name": "test"}, nil)
+	assert.NoError(t, err)
+}
+
+func TestUnsupportedAuthType(t *testing.T) {
+	cfg := mockConfigWithRetry
+	cfg.WithRetry = false
+	cfg.Auth = AuthConfig{Type: "kerberos"}
+	client := NewClient(cfg, logrus.New())
+
+	_, err := client.Get(context.Background(), "http://localhost", nil)
+	assert.ErrorContains(t, err, "unsupported auth type")
+}
+
+func TestSigV4Signature(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Contains(t, authorization, "/us-east-1/execute-api/aws4_request")
+		assert.NotEmpty(t, r.Header.Get("X-Amz-Date"))
+		assert.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithRetry
+	cfg.Auth = AuthConfig{Type: AuthSigV4, Service: "execute-api"}
+	awsCfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", "session"),
+	}
+	client := NewClient(cfg, logrus.New(), WithAWSConfig(awsCfg))
+
+	_, err := client.Post(context.Background(), ts.URL+"/internal/transfers", map[string]int{"amount": 10}, nil)
+	assert.NoError(t, err)
+}
+
+func TestSigV4WithoutCredentials(t *testing.T) {
+	cfg := mockConfigWithRetry
+	cfg.WithRetry = false
+	cfg.Auth = AuthConfig{Type: AuthSigV4, Service: "lambda", Region: "us-east-1"}
+	client := NewClient(cfg, logrus.New())
+
+	_, err := client.Get(context.Background(), "http://localhost", nil)
+	assert.ErrorContains(t, err, "sigv4 auth requires aws credentials")
+}
+
+type staticProvider struct{}
+
+func (staticProvider) Authenticate(req *http.Request) error {
+	req.Header.Set("X-Api-Key", "custom")
+	return nil
+}
+
+func TestCustomAuthProvider(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "custom", r.Header.Get("X-Api-Key"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New(), WithAuthProvider(staticProvider{}))
+
+	_, err := client.Get(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,350 @@
+package rest
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker/v2"
+)
+
+var (
+	_ CacheStore = (*memoryStore)(nil)
+	_ CacheStore = (*redisStore)(nil)
+)
+
+// WithCacheStore reemplaza el almacenamiento en memoria del cache HTTP.
+func WithCacheStore(store CacheStore) Option {
+	return func(o *clientOptions) {
+		o.cacheStore = store
+	}
+}
+
+// NewMemoryCacheStore crea un cache LRU en memoria con capacidad para capacity respuestas.
+func NewMemoryCacheStore(capacity int) CacheStore {
+	if capacity <= 0 {
+		capacity = DefaultCacheSize
+	}
+	return &memoryStore{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// NewRedisCacheStore guarda las respuestas cacheadas en Redis.
+func NewRedisCacheStore(client *redis.Client) CacheStore {
+	return &redisStore{client: client, prefix: "rest-cache:"}
+}
+
+func createCache(c Config, o clientOptions, l *logrus.Logger) *httpCache {
+	if !c.WithCache {
+		return nil
+	}
+	store := o.cacheStore
+	if store == nil {
+		if strings.EqualFold(c.CacheBackend, CacheBackendRedis) {
+			l.Warn("Cache redis sin WithCacheStore, se usa el cache en memoria", map[string]interface{}{
+				"base_url": c.BaseURL,
+			})
+		}
+		store = NewMemoryCacheStore(c.CacheSize)
+	}
+	retention := c.CacheRetention * time.Second
+	if retention <= 0 {
+		retention = DefaultCacheRetention
+	}
+	return &httpCache{store: store, retention: retention}
+}
+
+func (c *client) executeCached(ctx context.Context, req request) (*resty.Response, error) {
+	requestDirectives := parseCacheControl(headerValue(req.headers, "Cache-Control"))
+	if _, ok := requestDirectives["no-store"]; ok {
+		return c.execute(ctx, req)
+	}
+
+	key := http.MethodGet + " " + req.url
+	entry, ok := c.cache.store.Get(ctx, key)
+	if ok && !entry.matches(req.headers) {
+		ok = false
+	}
+	if _, noCache := requestDirectives["no-cache"]; ok && !noCache && entry.fresh(time.Now()) {
+		c.cache.hits.Add(1)
+		c.logCache(ctx, CacheHit, key)
+		return entry.response(c.requester.httpClient.R().SetContext(ctx), CacheHit), nil
+	}
+	if ok && entry.hasValidators() {
+		req.headers = conditionalHeaders(req.headers, entry)
+		req.cached = entry
+	}
+
+	resp, err := c.execute(ctx, req)
+	if err != nil {
+		if ok && errors.Is(err, gobreaker.ErrOpenState) {
+			c.cache.hits.Add(1)
+			c.logCache(ctx, CacheStale, key)
+			return entry.response(c.requester.httpClient.R().SetContext(ctx), CacheStale), nil
+		}
+		c.cache.misses.Add(1)
+		return nil, err
+	}
+	if resp.Header().Get(CacheStatusHeader) == CacheRevalidated {
+		c.cache.revalidations.Add(1)
+		c.logCache(ctx, CacheRevalidated, key)
+	} else {
+		c.cache.misses.Add(1)
+		c.logCache(ctx, CacheMiss, key)
+		resp.Header().Set(CacheStatusHeader, CacheMiss)
+	}
+	c.cache.save(ctx, key, req.headers, resp)
+	return resp, nil
+}
+
+func (c *client) logCache(ctx context.Context, status, key string) {
+	if c.logging {
+		c.logger.Debug(ctx, map[string]interface{}{
+			"event": "http_cache",
+			"cache": status,
+			"key":   key,
+		})
+	}
+}
+
+func (h *httpCache) stats() CacheStats {
+	return CacheStats{
+		Hits:          h.hits.Load(),
+		Misses:        h.misses.Load(),
+		Revalidations: h.revalidations.Load(),
+	}
+}
+
+func (h *httpCache) save(ctx context.Context, key string, reqHeaders map[string]string, resp *resty.Response) {
+	if resp.StatusCode() != http.StatusOK {
+		return
+	}
+	header := resp.Header().Clone()
+	header.Del(CacheStatusHeader)
+	directives := parseCacheControl(header.Get("Cache-Control"))
+	if _, ok := directives["no-store"]; ok {
+		return
+	}
+	if !shareable(reqHeaders, directives) {
+		return
+	}
+	vary, ok := varyValues(header, reqHeaders)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	freshness := freshnessLifetime(header, directives, now)
+	entry := &CacheEntry{
+		StatusCode: resp.StatusCode(),
+		Header:     header,
+		Body:       resp.Body(),
+		StoredAt:   now,
+		ExpiresAt:  now.Add(freshness),
+		Vary:       vary,
+	}
+	if !entry.hasValidators() && freshness <= 0 {
+		return
+	}
+	ttl := freshness
+	if entry.hasValidators() {
+		ttl += h.retention
+	}
+	h.store.Set(ctx, key, entry, ttl)
+}
+
+func (e *CacheEntry) fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+func (e *CacheEntry) hasValidators() bool {
+	return e.Header.Get("ETag") != "" || e.Header.Get("Last-Modified") != ""
+}
+
+func (e *CacheEntry) matches(reqHeaders map[string]string) bool {
+	for name, value := range e.Vary {
+		if headerValue(reqHeaders, name) != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *CacheEntry) response(r *resty.Request, status string) *resty.Response {
+	header := e.Header.Clone()
+	header.Set(CacheStatusHeader, status)
+	resp := NewResponse(e.StatusCode, header, e.Body)
+	resp.Request = r
+	return resp
+}
+
+func (e *CacheEntry) revalidated(r *resty.Request, notModified *resty.Response) *resty.Response {
+	updated := *e
+	updated.Header = e.Header.Clone()
+	for _, name := range []string{"Cache-Control", "Date", "Expires", "ETag", "Last-Modified", "Age"} {
+		if v := notModified.Header().Get(name); v != "" {
+			updated.Header.Set(name, v)
+		}
+	}
+	return updated.response(r, CacheRevalidated)
+}
+
+// shareable evita guardar respuestas propias de un usuario: las pedidas con Authorization
+// o marcadas como private solo se cachean si el servidor las declara public o con s-maxage.
+func shareable(reqHeaders map[string]string, directives map[string]string) bool {
+	_, private := directives["private"]
+	if !private && headerValue(reqHeaders, "Authorization") == "" {
+		return true
+	}
+	_, public := directives["public"]
+	_, sMaxAge := directives["s-maxage"]
+	return public || sMaxAge
+}
+
+func conditionalHeaders(headers map[string]string, e *CacheEntry) map[string]string {
+	h := make(map[string]string, len(headers)+2)
+	for k, v := range headers {
+		h[k] = v
+	}
+	if etag := e.Header.Get("ETag"); etag != "" {
+		h["If-None-Match"] = etag
+	}
+	if lastModified := e.Header.Get("Last-Modified"); lastModified != "" {
+		h["If-Modified-Since"] = lastModified
+	}
+	return h
+}
+
+func freshnessLifetime(header http.Header, directives map[string]string, now time.Time) time.Duration {
+	if _, ok := directives["no-cache"]; ok {
+		return 0
+	}
+	age := time.Duration(0)
+	if seconds, err := strconv.Atoi(header.Get("Age")); err == nil && seconds > 0 {
+		age = time.Duration(seconds) * time.Second
+	}
+	if maxAge, ok := directives["max-age"]; ok {
+		seconds, err := strconv.Atoi(maxAge)
+		if err != nil {
+			return 0
+		}
+		return time.Duration(seconds)*time.Second - age
+	}
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		date, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			date = now
+		}
+		return expires.Sub(date) - age
+	}
+	return 0
+}
+
+func varyValues(header http.Header, reqHeaders map[string]string) (map[string]string, bool) {
+	var vary map[string]string
+	for _, line := range header.Values("Vary") {
+		for _, name := range strings.Split(line, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if name == "*" {
+				return nil, false
+			}
+			if vary == nil {
+				vary = make(map[string]string)
+			}
+			vary[http.CanonicalHeaderKey(name)] = headerValue(reqHeaders, name)
+		}
+	}
+	return vary, true
+}
+
+func parseCacheControl(value string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(part, "=")
+		directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(arg), `"`)
+	}
+	return directives
+}
+
+func headerValue(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) (*CacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+	item := el.Value.(*memoryItem)
+	if time.Now().After(item.expiresAt) {
+		s.order.Remove(el)
+		delete(s.items, key)
+		return nil, false
+	}
+	s.order.MoveToFront(el)
+	return item.entry, true
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, entry *CacheEntry, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiresAt := time.Now().Add(ttl)
+	if el, ok := s.items[key]; ok {
+		item := el.Value.(*memoryItem)
+		item.entry = entry
+		item.expiresAt = expiresAt
+		s.order.MoveToFront(el)
+		return
+	}
+	s.items[key] = s.order.PushFront(&memoryItem{key: key, entry: entry, expiresAt: expiresAt})
+	if s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.items, oldest.Value.(*memoryItem).key)
+	}
+}
+
+func (s *redisStore) Get(ctx context.Context, key string) (*CacheEntry, bool) {
+	b, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	var entry CacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (s *redisStore) Set(ctx context.Context, key string, entry *CacheEntry, ttl time.Duration) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = s.client.Set(ctx, s.prefix+key, b, ttl).Err()
+}
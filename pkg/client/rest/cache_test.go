@@ -0,0 +1,194 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var mockConfigWithCache = Config{
+	WithCache: true,
+	CacheSize: 10,
+}
+
+func TestCacheHitWithMaxAge(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "max-age=60")
+		_, _ = w.Write([]byte(`{"currency": "ARS"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithCache, logrus.New())
+
+	first, err := client.Get(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, first.Header().Get(CacheStatusHeader))
+
+	second, err := client.Get(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheHit, second.Header().Get(CacheStatusHeader))
+	assert.Equal(t, `{"currency": "ARS"}`, string(second.Body()))
+	assert.Equal(t, http.StatusOK, second.StatusCode())
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 1}, client.CacheStats())
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "no-cache")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte(`{"rate": 1}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithCache, logrus.New())
+
+	_, err := client.Get(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+
+	resp, err := client.Get(context.Background(), ts.URL, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, CacheRevalidated, resp.Header().Get(CacheStatusHeader))
+	assert.Equal(t, `{"rate": 1}`, string(resp.Body()))
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, CacheStats{Misses: 1, Revalidations: 1}, client.CacheStats())
+}
+
+func TestCacheSkipsNoStore(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "no-store, max-age=60")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithCache, logrus.New())
+	for i := 0; i < 2; i++ {
+		_, err := client.Get(context.Background(), ts.URL, nil)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestCacheVary(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Vary", "Accept-Language")
+		_, _ = w.Write([]byte(r.Header.Get("Accept-Language")))
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithCache, logrus.New())
+
+	_, _ = client.Get(context.Background(), ts.URL, map[string]string{"Accept-Language": "es"})
+	resp, err := client.Get(context.Background(), ts.URL, map[string]string{"Accept-Language": "en"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "en", string(resp.Body()))
+	assert.Equal(t, 2, calls)
+}
+
+func TestCacheSkipsPrivateResponses(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/private":
+			w.Header().Set("Cache-Control", "private, max-age=60")
+		case "/public":
+			w.Header().Set("Cache-Control", "public, max-age=60")
+		default:
+			w.Header().Set("Cache-Control", "max-age=60")
+		}
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithCache, logrus.New())
+
+	_, _ = client.Get(context.Background(), ts.URL+"/me", map[string]string{"Authorization": "Bearer alice"})
+	resp, err := client.Get(context.Background(), ts.URL+"/me", map[string]string{"Authorization": "Bearer bob"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer bob", string(resp.Body()))
+	assert.Equal(t, CacheMiss, resp.Header().Get(CacheStatusHeader))
+
+	_, _ = client.Get(context.Background(), ts.URL+"/private", nil)
+	resp, err = client.Get(context.Background(), ts.URL+"/private", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, resp.Header().Get(CacheStatusHeader))
+
+	_, _ = client.Get(context.Background(), ts.URL+"/public", map[string]string{"Authorization": "Bearer alice"})
+	resp, err = client.Get(context.Background(), ts.URL+"/public", map[string]string{"Authorization": "Bearer bob"})
+	assert.NoError(t, err)
+	assert.Equal(t, CacheHit, resp.Header().Get(CacheStatusHeader))
+
+	assert.Equal(t, 5, calls)
+}
+
+func TestCacheRedisBackendWithoutStoreWarns(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	cfg := mockConfigWithCache
+	cfg.CacheBackend = CacheBackendRedis
+
+	client := NewClient(cfg, logger)
+
+	assert.NotNil(t, client.cache)
+	assert.Len(t, hook.Entries, 1)
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+
+	hook.Reset()
+	NewClient(cfg, logger, WithCacheStore(NewMemoryCacheStore(1)))
+	assert.Empty(t, hook.Entries)
+}
+
+func TestMemoryCacheStoreEviction(t *testing.T) {
+	store := NewMemoryCacheStore(2)
+	ctx := context.Background()
+
+	store.Set(ctx, "a", &CacheEntry{}, time.Minute)
+	store.Set(ctx, "b", &CacheEntry{}, time.Minute)
+	_, _ = store.Get(ctx, "a")
+	store.Set(ctx, "c", &CacheEntry{}, time.Minute)
+
+	_, ok := store.Get(ctx, "a")
+	assert.True(t, ok)
+	_, ok = store.Get(ctx, "b")
+	assert.False(t, ok)
+
+	store.Set(ctx, "expired", &CacheEntry{}, -time.Second)
+	_, ok = store.Get(ctx, "expired")
+	assert.False(t, ok)
+}
+
+func TestFreshnessLifetime(t *testing.T) {
+	now := time.Now()
+	header := http.Header{}
+	header.Set("Age", "10")
+	assert.Equal(t, 50*time.Second, freshnessLifetime(header, parseCacheControl("public, max-age=60"), now))
+
+	header = http.Header{}
+	header.Set("Date", now.UTC().Format(http.TimeFormat))
+	header.Set("Expires", now.Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.InDelta(t, float64(time.Hour), float64(freshnessLifetime(header, parseCacheControl(""), now)), float64(time.Second))
+}
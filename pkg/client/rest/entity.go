@@ -1,26 +1,90 @@
 package rest
 
 import (
+	"container/list"
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/go-resty/resty/v2"
+	"github.com/redis/go-redis/v9"
 	"github.com/sony/gobreaker/v2"
+	"golang.org/x/time/rate"
 )
 
 const (
-	BackoffFactor                    = 2.0
-	MaxJitterPercentage              = 0.5
-	DefaultRetryCount         uint32 = 3
-	DefaultRetryWaitTime             = 100 * time.Millisecond
-	DefaultRetryMaxWaitTime          = 500 * time.Millisecond
-	DefaultCBMaxRequests      uint32 = 5
-	DefaultCBInterval                = 10 * time.Second
-	DefaultCBTimeout                 = 5 * time.Second
-	DefaultCBRequestThreshold uint32 = 5
-	DefaultCBFailureRateLimit        = 50.0
+	BackoffFactor                     = 2.0
+	MaxJitterPercentage               = 0.5
+	DefaultRetryCount          uint32 = 3
+	DefaultRetryWaitTime              = 100 * time.Millisecond
+	DefaultRetryMaxWaitTime           = 500 * time.Millisecond
+	DefaultCBMaxRequests       uint32 = 5
+	DefaultCBInterval                 = 10 * time.Second
+	DefaultCBTimeout                  = 5 * time.Second
+	DefaultCBRequestThreshold  uint32 = 5
+	DefaultCBFailureRateLimit         = 50.0
+	OAuth2RefreshWindow               = 30 * time.Second
+	DefaultOAuth2TokenLifetime        = 5 * time.Minute
+	DefaultCacheSize                  = 1000
+	DefaultCacheRetention             = 5 * time.Minute
+)
+
+const (
+	maxErrorBodySize  = 64 << 10
+	bodyPreviewLength = 200
+	errorCodePrefix   = "ERR-"
+)
+
+const (
+	CacheBackendMemory = "memory"
+	CacheBackendRedis  = "redis"
+	CacheStatusHeader  = "X-Cache"
+	CacheHit           = "HIT"
+	CacheMiss          = "MISS"
+	CacheRevalidated   = "REVALIDATED"
+	CacheStale         = "STALE"
+)
+
+const (
+	AuthBearer             = "bearer"
+	AuthBasic              = "basic"
+	AuthOAuth2             = "oauth2"
+	AuthHMAC               = "hmac"
+	AuthSigV4              = "sigv4"
+	DefaultSignatureHeader = "X-Signature"
+	TimestampHeader        = "X-Timestamp"
+	KeyIDHeader            = "X-Key-Id"
+)
+
+var (
+	ErrRateLimitExceeded   = errors.New("rate limit exceeded")
+	ErrMaxInFlightExceeded = errors.New("max in-flight requests exceeded")
+)
+
+var (
+	DefaultRetryStatusCodes = []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+	DefaultRetryMethods = []string{
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodPut,
+		http.MethodDelete,
+	}
 )
 
 type Config struct {
@@ -31,6 +95,8 @@ type Config struct {
 	RetryCount         uint32
 	RetryWaitTime      time.Duration
 	RetryMaxWaitTime   time.Duration
+	RetryStatusCodes   []int
+	RetryMethods       []string
 	WithCB             bool
 	CBName             string
 	CBMaxRequests      uint32
@@ -38,25 +104,280 @@ type Config struct {
 	CBTimeout          time.Duration
 	CBRequestThreshold uint32
 	CBFailureRateLimit float64
+	CBPerRoute         bool
+	RateLimit          float64
+	RateBurst          int
+	MaxInFlight        int
+	HedgeDelay         time.Duration
+	Auth               AuthConfig
+	WithCache          bool
+	CacheBackend       string
+	CacheSize          int
+	CacheRetention     time.Duration
+}
+
+type AuthConfig struct {
+	Type            string
+	Token           string
+	TokenEnv        string
+	Username        string
+	Password        string
+	TokenURL        string
+	ClientID        string
+	ClientSecret    string
+	Scopes          []string
+	KeyID           string
+	Secret          string
+	SignatureHeader string
+	Service         string
+	Region          string
+}
+
+// AuthProvider agrega credenciales a cada solicitud saliente, incluidos los reintentos.
+type AuthProvider interface {
+	Authenticate(req *http.Request) error
+}
+
+// tokenInvalidator lo implementan los proveedores con token cacheado para descartarlo
+// cuando el servidor responde 401.
+type tokenInvalidator interface {
+	invalidate(req *http.Request)
+}
+
+// Interceptor agrega comportamiento transversal a cada llamada del cliente.
+// BeforeRequest recibe la solicitud ya armada y puede modificarla o cortarla con un error;
+// AfterResponse recibe el resultado crudo (antes de validar el status) y devuelve el error final.
+// Los interceptores se ejecutan en orden de registro antes de la solicitud y en orden inverso después.
+// Se ejecutan una vez por solicitud lanzada, alrededor de todos sus reintentos: AfterResponse recibe el
+// resultado del último intento (req.Attempt indica cuántos hubo) y los headers de BeforeRequest se repiten
+// en cada uno. Con HedgeDelay la solicitud duplicada tiene su propio *resty.Request y vuelve a ejecutar
+// la cadena, por lo que una llamada con hedge puede ejecutar BeforeRequest y AfterResponse dos veces.
+type Interceptor interface {
+	BeforeRequest(ctx context.Context, req *resty.Request) error
+	AfterResponse(ctx context.Context, req *resty.Request, resp *resty.Response, err error) error
+}
+
+// InterceptorFuncs adapta funciones sueltas a Interceptor; los hooks nil se ignoran.
+type InterceptorFuncs struct {
+	Before func(ctx context.Context, req *resty.Request) error
+	After  func(ctx context.Context, req *resty.Request, resp *resty.Response, err error) error
+}
+
+type Option func(*clientOptions)
+
+// Fallback arma una respuesta alternativa cuando el Circuit Breaker está abierto.
+// route es el endpoint sin resolver; err es el error original del breaker.
+type Fallback func(ctx context.Context, route string, err error) (*resty.Response, error)
+
+// CacheStore persiste las respuestas cacheadas de las solicitudes GET.
+type CacheStore interface {
+	Get(ctx context.Context, key string) (*CacheEntry, bool)
+	Set(ctx context.Context, key string, entry *CacheEntry, ttl time.Duration)
+}
+
+type CacheEntry struct {
+	StatusCode int               `json:"status_code"`
+	Header     http.Header       `json:"header"`
+	Body       []byte            `json:"body"`
+	StoredAt   time.Time         `json:"stored_at"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	Vary       map[string]string `json:"vary,omitempty"`
+}
+
+type httpCache struct {
+	store         CacheStore
+	retention     time.Duration
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	revalidations atomic.Uint64
+}
+
+type memoryStore struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type memoryItem struct {
+	key       string
+	entry     *CacheEntry
+	expiresAt time.Time
+}
+
+type redisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+type CacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Revalidations uint64
 }
 
 type Service interface {
-	Get(ctx context.Context, endpoint string, headers map[string]string) (*resty.Response, error)
-	Post(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error)
-	Put(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error)
-	Patch(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error)
-	Delete(ctx context.Context, endpoint string, headers map[string]string) (*resty.Response, error)
+	Get(ctx context.Context, endpoint string, headers map[string]string, opts ...RequestOption) (*resty.Response, error)
+	Post(ctx context.Context, endpoint string, body interface{}, headers map[string]string, opts ...RequestOption) (*resty.Response, error)
+	Put(ctx context.Context, endpoint string, body interface{}, headers map[string]string, opts ...RequestOption) (*resty.Response, error)
+	Patch(ctx context.Context, endpoint string, body interface{}, headers map[string]string, opts ...RequestOption) (*resty.Response, error)
+	Delete(ctx context.Context, endpoint string, headers map[string]string, opts ...RequestOption) (*resty.Response, error)
+	PostForm(ctx context.Context, endpoint string, form url.Values, headers map[string]string, opts ...RequestOption) (*resty.Response, error)
+	PostMultipart(ctx context.Context, endpoint string, fields map[string]string, files []File, headers map[string]string, opts ...RequestOption) (*resty.Response, error)
+	Download(ctx context.Context, endpoint string, w io.Writer, headers map[string]string, opts ...RequestOption) (*resty.Response, error)
 	WithLogging(enable bool)
+	Use(interceptors ...Interceptor)
+	CacheStats() CacheStats
+	Breakers() map[string]gobreaker.State
+	State(name string) (gobreaker.State, bool)
 }
 
 type client struct {
-	baseURL   string
+	mu           sync.RWMutex
+	baseURL      string
+	requester    *requester
+	logger       *logrus.Logger
+	logging      bool
+	interceptors []Interceptor
+	cache        *httpCache
+	hedgeDelay   time.Duration
+	fallback     Fallback
+}
+
+type request struct {
+	method  string
+	route   string
+	url     string
+	headers map[string]string
+	options requestOptions
+	prepare func(r *resty.Request)
+	cached  *CacheEntry
+	stream  io.Writer
+}
+
+// File es un archivo a enviar en una solicitud multipart/form-data.
+type File struct {
+	Field       string
+	Name        string
+	ContentType string
+	Reader      io.Reader
+}
+
+type clientOptions struct {
+	auth         AuthProvider
+	interceptors []Interceptor
+	cacheStore   CacheStore
+	fallback     Fallback
+	awsConfig    *aws.Config
+}
+
+type authTransport struct {
+	base     http.RoundTripper
+	provider AuthProvider
+}
+
+type limitTransport struct {
+	base      http.RoundTripper
 	requester *requester
-	logger    *logrus.Logger
-	logging   bool
+}
+
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+type bearerProvider struct {
+	token string
+}
+
+type basicProvider struct {
+	username string
+	password string
+}
+
+type oauth2Provider struct {
+	mu         sync.Mutex
+	cfg        AuthConfig
+	httpClient *http.Client
+	token      string
+	refreshAt  time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type hmacProvider struct {
+	keyID  string
+	secret []byte
+	header string
+}
+
+type sigV4Provider struct {
+	signer      *v4.Signer
+	credentials aws.CredentialsProvider
+	service     string
+	region      string
+}
+
+type invalidProvider struct {
+	err error
+}
+
+type hedgeResult struct {
+	resp *resty.Response
+	err  error
+}
+
+// permit es el token y slot en vuelo que el hedge tomó antes de lanzar la segunda solicitud;
+// lo consume su primer intento en limitTransport en lugar de volver a esperar.
+type permit struct {
+	used    atomic.Bool
+	release func()
+}
+
+type permitKey struct{}
+
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	pathParams map[string]string
+	query      url.Values
+}
+
+type routeKey struct{}
+
+type JSONOption func(*jsonOptions)
+
+type jsonOptions struct {
+	errorBody      interface{}
+	requestOptions []RequestOption
+}
+
+// HTTPError describe una respuesta no 2xx del servicio remoto. Body contiene el cuerpo completo.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte
+	Method     string
+	URL        string
+	Attempts   int
 }
 
 type requester struct {
 	httpClient *resty.Client
-	breaker    *gobreaker.CircuitBreaker[any]
+	breakers   *breakerRegistry
+	limiter    *rate.Limiter
+	inFlight   chan struct{}
+}
+
+type breakerRegistry struct {
+	mu       sync.RWMutex
+	name     string
+	perRoute bool
+	settings func(name string) gobreaker.Settings
+	breakers map[string]*gobreaker.CircuitBreaker[any]
 }
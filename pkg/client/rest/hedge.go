@@ -0,0 +1,136 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// WithFallback define la respuesta a devolver cuando el Circuit Breaker está abierto.
+// Con cache habilitado, antes se intenta servir la última respuesta guardada (X-Cache: STALE).
+func WithFallback(f Fallback) Option {
+	return func(o *clientOptions) {
+		o.fallback = f
+	}
+}
+
+// NewResponse arma una respuesta sintética, p.ej. un valor por defecto desde un Fallback.
+func NewResponse(statusCode int, header http.Header, body []byte) *resty.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	resp := &resty.Response{
+		RawResponse: &http.Response{
+			StatusCode: statusCode,
+			Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+			Header:     header,
+		},
+	}
+	return resp.SetBody(body)
+}
+
+func (c *client) runFallback(ctx context.Context, err error) (*resty.Response, error) {
+	resp, fallbackErr := c.fallback(ctx, RouteFromContext(ctx), err)
+	if fallbackErr != nil {
+		return nil, fallbackErr
+	}
+	if resp != nil && resp.Request == nil {
+		resp.Request = c.requester.httpClient.R().SetContext(ctx)
+	}
+	c.logFallback(ctx, err)
+	return resp, nil
+}
+
+// hedgeable limita el hedging a métodos seguros y sin streaming, donde duplicar la solicitud no tiene efectos.
+func hedgeable(req request) bool {
+	if req.stream != nil {
+		return false
+	}
+	switch req.method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// hedge lanza una segunda solicitud si la primera no respondió dentro de hedgeDelay y devuelve
+// la primera respuesta exitosa; si ambas fallan devuelve el último resultado.
+// La segunda solicitud solo sale si hay un token y un slot en vuelo disponibles en ese momento.
+func (c *client) hedge(reqFunc func(ctx context.Context) (*resty.Response, error)) func(ctx context.Context) (*resty.Response, error) {
+	return func(ctx context.Context) (*resty.Response, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results := make(chan hedgeResult, 2)
+		attempt := func(ctx context.Context) {
+			resp, err := reqFunc(ctx)
+			results <- hedgeResult{resp: resp, err: err}
+		}
+		go attempt(ctx)
+
+		timer := time.NewTimer(c.hedgeDelay)
+		defer timer.Stop()
+
+		pending := 1
+		for {
+			select {
+			case <-timer.C:
+				release, ok := c.requester.tryAcquire()
+				if !ok {
+					c.logHedge(ctx, "hedge_skipped")
+					continue
+				}
+				p := &permit{release: release}
+				pending++
+				c.logHedge(ctx, "hedged_request")
+				go func() {
+					attempt(context.WithValue(ctx, permitKey{}, p))
+					if release, ok := p.take(); ok {
+						release()
+					}
+				}()
+			case res := <-results:
+				pending--
+				if pending == 0 || (res.err == nil && validateResponse(res.resp) == nil) {
+					return res.resp, res.err
+				}
+			}
+		}
+	}
+}
+
+// take devuelve la liberación del permiso solo la primera vez que se llama.
+func (p *permit) take() (func(), bool) {
+	if p.used.Swap(true) {
+		return nil, false
+	}
+	return p.release, true
+}
+
+func permitFromContext(ctx context.Context) (*permit, bool) {
+	p, ok := ctx.Value(permitKey{}).(*permit)
+	return p, ok
+}
+
+func (c *client) logHedge(ctx context.Context, event string) {
+	if c.logging {
+		c.logger.Info(ctx, event, map[string]interface{}{
+			"event": event,
+			"route": RouteFromContext(ctx),
+			"delay": c.hedgeDelay.String(),
+		})
+	}
+}
+
+func (c *client) logFallback(ctx context.Context, err error) {
+	if c.logging {
+		c.logger.Warn(ctx, "fallback", map[string]interface{}{
+			"event": "fallback",
+			"route": RouteFromContext(ctx),
+			"error": err,
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedgedRequestReturnsFastestResponse(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	client := NewClient(Config{BaseURL: ts.URL, HedgeDelay: 20}, logrus.New())
+
+	start := time.Now()
+	resp, err := client.Get(context.Background(), "/quotes", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", string(resp.Body()))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestHedgedRequestRunsInterceptorsPerRequest(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	var before, after atomic.Int32
+	client := NewClient(Config{BaseURL: ts.URL, HedgeDelay: 20}, logrus.New(), WithInterceptors(InterceptorFuncs{
+		Before: func(ctx context.Context, req *resty.Request) error {
+			before.Add(1)
+			return nil
+		},
+		After: func(ctx context.Context, req *resty.Request, resp *resty.Response, err error) error {
+			after.Add(1)
+			return err
+		},
+	}))
+
+	_, err := client.Get(context.Background(), "/quotes", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), before.Load())
+	assert.Eventually(t, func() bool { return after.Load() == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestHedgeSkippedWithoutCapacity(t *testing.T) {
+	configs := map[string]Config{
+		"max_in_flight": {HedgeDelay: 20, MaxInFlight: 1},
+		"rate_limit":    {HedgeDelay: 20, RateLimit: 1, RateBurst: 1},
+	}
+	for name, cfg := range configs {
+		t.Run(name, func(t *testing.T) {
+			var calls, inFlight, maxInFlight atomic.Int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				if n > maxInFlight.Load() {
+					maxInFlight.Store(n)
+				}
+				time.Sleep(150 * time.Millisecond)
+				_, _ = w.Write([]byte("ok"))
+			}))
+			defer ts.Close()
+
+			cfg.BaseURL = ts.URL
+			client := NewClient(cfg, logrus.New())
+			resp, err := client.Get(context.Background(), "/quotes", nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, "ok", string(resp.Body()))
+			assert.Equal(t, int32(1), calls.Load())
+			assert.Equal(t, int32(1), maxInFlight.Load())
+		})
+	}
+}
+
+func TestHedgeNotAppliedToPost(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	client := NewClient(Config{BaseURL: ts.URL, HedgeDelay: 10}, logrus.New())
+
+	_, err := client.Post(context.Background(), "/transfers", map[string]int{"amount": 10}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestFallbackWhenCircuitBreakerOpen(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Server error", http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	var fallbackRoute string
+	cfg := mockConfigWithCB
+	cfg.BaseURL = ts.URL
+	cfg.CBMaxRequests = 1
+	client := NewClient(cfg, logrus.New(), WithFallback(func(ctx context.Context, route string, err error) (*resty.Response, error) {
+		fallbackRoute = route
+		return NewResponse(http.StatusOK, nil, []byte(`{"rate": 0}`)), nil
+	}))
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Get(context.Background(), "/rates/{currency}", nil, WithPathParam("currency", "ARS"))
+		assert.Error(t, err)
+	}
+	resp, err := client.Get(context.Background(), "/rates/{currency}", nil, WithPathParam("currency", "ARS"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, `{"rate": 0}`, string(resp.Body()))
+	assert.Equal(t, "/rates/{currency}", fallbackRoute)
+}
+
+func TestStaleCacheWhenCircuitBreakerOpen(t *testing.T) {
+	var failing atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			http.Error(w, "Server error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "no-cache")
+		_, _ = w.Write([]byte(`{"rate": 1}`))
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithCB
+	cfg.BaseURL = ts.URL
+	cfg.CBMaxRequests = 1
+	cfg.WithCache = true
+	client := NewClient(cfg, logrus.New())
+
+	_, err := client.Get(context.Background(), "/rates", nil)
+	assert.NoError(t, err)
+
+	failing.Store(true)
+	for i := 0; i < 2; i++ {
+		_, _ = client.Get(context.Background(), "/rates", nil)
+	}
+	resp, err := client.Get(context.Background(), "/rates", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, CacheStale, resp.Header().Get(CacheStatusHeader))
+	assert.Equal(t, `{"rate": 1}`, string(resp.Body()))
+}
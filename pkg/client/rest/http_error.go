@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/error_handler"
+)
+
+var _ error = (*HTTPError)(nil)
+
+// AsHTTPError devuelve el HTTPError contenido en err, si existe.
+func AsHTTPError(err error) (*HTTPError, bool) {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr, true
+	}
+	return nil, false
+}
+
+func newHTTPError(resp *resty.Response) *HTTPError {
+	e := &HTTPError{
+		StatusCode: resp.StatusCode(),
+		Status:     resp.Status(),
+		Header:     resp.Header(),
+		Body:       resp.Body(),
+	}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.URL = resp.Request.URL
+		e.Attempts = resp.Request.Attempt
+	}
+	return e
+}
+
+func (e *HTTPError) Error() string {
+	bodyPreview := string(e.Body)
+	if len(bodyPreview) > bodyPreviewLength {
+		bodyPreview = bodyPreview[:bodyPreviewLength] + "..."
+	}
+	return fmt.Sprintf("HTTP %d: %s - %s", e.StatusCode, e.Status, bodyPreview)
+}
+
+// ToCommonApiError traduce el error del servicio remoto a un CommonApiError.
+// Los 4xx se propagan con el mismo status salvo 401 y 403, que junto con los 5xx
+// se informan como 502 (504 si el remoto respondió timeout). Si el cuerpo ya es
+// un CommonApiError se reutilizan su código y mensaje.
+func (e *HTTPError) ToCommonApiError() error {
+	httpCode := http.StatusBadGateway
+	switch {
+	case e.StatusCode == http.StatusGatewayTimeout:
+		httpCode = http.StatusGatewayTimeout
+	case e.StatusCode >= 400 && e.StatusCode < 500 &&
+		e.StatusCode != http.StatusUnauthorized && e.StatusCode != http.StatusForbidden:
+		httpCode = e.StatusCode
+	}
+
+	code := fmt.Sprintf("%s%d", errorCodePrefix, httpCode)
+	msg := http.StatusText(httpCode)
+	var remote error_handler.CommonApiError
+	if err := json.Unmarshal(e.Body, &remote); err == nil && remote.Code != "" {
+		code = remote.Code
+		msg = remote.Msg
+	}
+	return error_handler.NewCommonApiError(code, msg, e, httpCode)
+}
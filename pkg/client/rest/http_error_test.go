@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/error_handler"
+)
+
+func TestHTTPErrorCarriesFullResponse(t *testing.T) {
+	body := strings.Repeat("x", 300)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		http.Error(w, body, http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithRetry
+	cfg.RetryWaitTime = 10
+	cfg.RetryMaxWaitTime = 1
+	client := NewClient(cfg, logrus.New())
+	_, err := client.Get(context.Background(), ts.URL+"/users", nil)
+
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	assert.Equal(t, http.MethodGet, httpErr.Method)
+	assert.Equal(t, ts.URL+"/users", httpErr.URL)
+	assert.Equal(t, 3, httpErr.Attempts)
+	assert.Equal(t, "req-1", httpErr.Header.Get("X-Request-Id"))
+	assert.Equal(t, body+"\n", string(httpErr.Body))
+	assert.Contains(t, err.Error(), "HTTP 503")
+	assert.Less(t, len(err.Error()), 300)
+}
+
+func TestHTTPErrorToCommonApiError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *HTTPError
+		httpCode int
+		code     string
+		msg      string
+	}{
+		{"not found", &HTTPError{StatusCode: http.StatusNotFound}, http.StatusNotFound, "ERR-404", "Not Found"},
+		{"unauthorized", &HTTPError{StatusCode: http.StatusUnauthorized}, http.StatusBadGateway, "ERR-502", "Bad Gateway"},
+		{"server error", &HTTPError{StatusCode: http.StatusInternalServerError}, http.StatusBadGateway, "ERR-502", "Bad Gateway"},
+		{"timeout", &HTTPError{StatusCode: http.StatusGatewayTimeout}, http.StatusGatewayTimeout, "ERR-504", "Gateway Timeout"},
+		{"remote api error", &HTTPError{StatusCode: http.StatusConflict, Body: []byte(`{"code": "ACC-001", "msg": "cuenta bloqueada"}`)}, http.StatusConflict, "ACC-001", "cuenta bloqueada"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiErr *error_handler.CommonApiError
+			assert.True(t, errors.As(tt.err.ToCommonApiError(), &apiErr))
+			assert.Equal(t, tt.httpCode, apiErr.HttpCode)
+			assert.Equal(t, tt.code, apiErr.Code)
+			assert.Equal(t, tt.msg, apiErr.Msg)
+			assert.Equal(t, tt.err, apiErr.Err)
+		})
+	}
+}
+
+func TestAsHTTPError(t *testing.T) {
+	_, ok := AsHTTPError(errors.New("network"))
+	assert.False(t, ok)
+
+	httpErr, ok := AsHTTPError(errors.Join(errors.New("wrapped"), &HTTPError{StatusCode: http.StatusBadRequest}))
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+}
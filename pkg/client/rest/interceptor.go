@@ -0,0 +1,30 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+)
+
+var _ Interceptor = InterceptorFuncs{}
+
+// WithInterceptors registra interceptores al construir el cliente.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(o *clientOptions) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
+func (f InterceptorFuncs) BeforeRequest(ctx context.Context, req *resty.Request) error {
+	if f.Before == nil {
+		return nil
+	}
+	return f.Before(ctx, req)
+}
+
+func (f InterceptorFuncs) AfterResponse(ctx context.Context, req *resty.Request, resp *resty.Response, err error) error {
+	if f.After == nil {
+		return err
+	}
+	return f.After(ctx, req, resp, err)
+}
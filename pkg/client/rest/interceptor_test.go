@@ -0,0 +1,142 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterceptorsOrderAndHeaders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "corr-1", r.Header.Get("X-Correlation-Id"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var calls []string
+	first := InterceptorFuncs{
+		Before: func(ctx context.Context, req *resty.Request) error {
+			calls = append(calls, "before-1")
+			req.SetHeader("X-Correlation-Id", "corr-1")
+			return nil
+		},
+		After: func(ctx context.Context, req *resty.Request, resp *resty.Response, err error) error {
+			calls = append(calls, "after-1")
+			return err
+		},
+	}
+	second := InterceptorFuncs{
+		Before: func(ctx context.Context, req *resty.Request) error {
+			calls = append(calls, "before-2")
+			return nil
+		},
+		After: func(ctx context.Context, req *resty.Request, resp *resty.Response, err error) error {
+			calls = append(calls, "after-2")
+			assert.Equal(t, http.StatusOK, resp.StatusCode())
+			return err
+		},
+	}
+
+	client := NewClient(mockConfigWithRetry, logrus.New(), WithInterceptors(first))
+	client.Use(second)
+
+	_, err := client.Get(context.Background(), ts.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"before-1", "before-2", "after-2", "after-1"}, calls)
+}
+
+func TestInterceptorAbortsRequest(t *testing.T) {
+	called := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New())
+	client.Use(InterceptorFuncs{
+		Before: func(ctx context.Context, req *resty.Request) error {
+			return errors.New("blocked")
+		},
+	})
+
+	_, err := client.Get(context.Background(), ts.URL, nil)
+
+	assert.EqualError(t, err, "blocked")
+	assert.False(t, called)
+}
+
+func TestInterceptorsRunOncePerCall(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var before, after, attempts int
+	cfg := mockConfigWithRetry
+	cfg.RetryWaitTime = 10
+	client := NewClient(cfg, logrus.New(), WithInterceptors(InterceptorFuncs{
+		Before: func(ctx context.Context, req *resty.Request) error {
+			before++
+			return nil
+		},
+		After: func(ctx context.Context, req *resty.Request, resp *resty.Response, err error) error {
+			after++
+			attempts = req.Attempt
+			return err
+		},
+	}))
+
+	_, err := client.Get(context.Background(), ts.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 1, before)
+	assert.Equal(t, 1, after)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestInterceptorErrorReleasesDownload(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("report"))
+	}))
+	defer ts.Close()
+
+	rejected := false
+	cfg := mockConfigWithRetry
+	cfg.MaxInFlight = 1
+	client := NewClient(cfg, logrus.New(), WithInterceptors(InterceptorFuncs{
+		After: func(ctx context.Context, req *resty.Request, resp *resty.Response, err error) error {
+			if !rejected {
+				rejected = true
+				return errors.New("rejected")
+			}
+			return err
+		},
+	}))
+
+	var buf bytes.Buffer
+	_, err := client.Download(context.Background(), ts.URL, &buf, nil)
+	assert.EqualError(t, err, "rejected")
+	assert.Empty(t, buf.String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err = client.Download(ctx, ts.URL, &buf, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "report", buf.String())
+}
@@ -4,10 +4,17 @@ package mocks
 
 import (
 	context "context"
+	io "io"
+
+	gobreaker "github.com/sony/gobreaker/v2"
 
 	mock "github.com/stretchr/testify/mock"
 
+	rest "github.com/uala-challenge/simple-toolkit/pkg/client/rest"
+
 	resty "github.com/go-resty/resty/v2"
+
+	url "net/url"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -15,9 +22,54 @@ type Service struct {
 	mock.Mock
 }
 
-// Delete provides a mock function with given fields: ctx, endpoint, headers
-func (_m *Service) Delete(ctx context.Context, endpoint string, headers map[string]string) (*resty.Response, error) {
-	ret := _m.Called(ctx, endpoint, headers)
+// Breakers provides a mock function with no fields
+func (_m *Service) Breakers() map[string]gobreaker.State {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Breakers")
+	}
+
+	var r0 map[string]gobreaker.State
+	if rf, ok := ret.Get(0).(func() map[string]gobreaker.State); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]gobreaker.State)
+		}
+	}
+
+	return r0
+}
+
+// CacheStats provides a mock function with no fields
+func (_m *Service) CacheStats() rest.CacheStats {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CacheStats")
+	}
+
+	var r0 rest.CacheStats
+	if rf, ok := ret.Get(0).(func() rest.CacheStats); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(rest.CacheStats)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, endpoint, headers, opts
+func (_m *Service) Delete(ctx context.Context, endpoint string, headers map[string]string, opts ...rest.RequestOption) (*resty.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, endpoint, headers)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
@@ -25,19 +77,56 @@ func (_m *Service) Delete(ctx context.Context, endpoint string, headers map[stri
 
 	var r0 *resty.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) (*resty.Response, error)); ok {
-		return rf(ctx, endpoint, headers)
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string, ...rest.RequestOption) (*resty.Response, error)); ok {
+		return rf(ctx, endpoint, headers, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string, ...rest.RequestOption) *resty.Response); ok {
+		r0 = rf(ctx, endpoint, headers, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*resty.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]string, ...rest.RequestOption) error); ok {
+		r1 = rf(ctx, endpoint, headers, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Download provides a mock function with given fields: ctx, endpoint, w, headers, opts
+func (_m *Service) Download(ctx context.Context, endpoint string, w io.Writer, headers map[string]string, opts ...rest.RequestOption) (*resty.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, endpoint, w, headers)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Download")
+	}
+
+	var r0 *resty.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Writer, map[string]string, ...rest.RequestOption) (*resty.Response, error)); ok {
+		return rf(ctx, endpoint, w, headers, opts...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) *resty.Response); ok {
-		r0 = rf(ctx, endpoint, headers)
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Writer, map[string]string, ...rest.RequestOption) *resty.Response); ok {
+		r0 = rf(ctx, endpoint, w, headers, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*resty.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]string) error); ok {
-		r1 = rf(ctx, endpoint, headers)
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Writer, map[string]string, ...rest.RequestOption) error); ok {
+		r1 = rf(ctx, endpoint, w, headers, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -45,9 +134,16 @@ func (_m *Service) Delete(ctx context.Context, endpoint string, headers map[stri
 	return r0, r1
 }
 
-// Get provides a mock function with given fields: ctx, endpoint, headers
-func (_m *Service) Get(ctx context.Context, endpoint string, headers map[string]string) (*resty.Response, error) {
-	ret := _m.Called(ctx, endpoint, headers)
+// Get provides a mock function with given fields: ctx, endpoint, headers, opts
+func (_m *Service) Get(ctx context.Context, endpoint string, headers map[string]string, opts ...rest.RequestOption) (*resty.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, endpoint, headers)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
@@ -55,19 +151,19 @@ func (_m *Service) Get(ctx context.Context, endpoint string, headers map[string]
 
 	var r0 *resty.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) (*resty.Response, error)); ok {
-		return rf(ctx, endpoint, headers)
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string, ...rest.RequestOption) (*resty.Response, error)); ok {
+		return rf(ctx, endpoint, headers, opts...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) *resty.Response); ok {
-		r0 = rf(ctx, endpoint, headers)
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string, ...rest.RequestOption) *resty.Response); ok {
+		r0 = rf(ctx, endpoint, headers, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*resty.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]string) error); ok {
-		r1 = rf(ctx, endpoint, headers)
+	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]string, ...rest.RequestOption) error); ok {
+		r1 = rf(ctx, endpoint, headers, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,9 +171,16 @@ func (_m *Service) Get(ctx context.Context, endpoint string, headers map[string]
 	return r0, r1
 }
 
-// Patch provides a mock function with given fields: ctx, endpoint, body, headers
-func (_m *Service) Patch(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error) {
-	ret := _m.Called(ctx, endpoint, body, headers)
+// Patch provides a mock function with given fields: ctx, endpoint, body, headers, opts
+func (_m *Service) Patch(ctx context.Context, endpoint string, body interface{}, headers map[string]string, opts ...rest.RequestOption) (*resty.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, endpoint, body, headers)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Patch")
@@ -85,19 +188,19 @@ func (_m *Service) Patch(ctx context.Context, endpoint string, body interface{},
 
 	var r0 *resty.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string) (*resty.Response, error)); ok {
-		return rf(ctx, endpoint, body, headers)
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string, ...rest.RequestOption) (*resty.Response, error)); ok {
+		return rf(ctx, endpoint, body, headers, opts...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string) *resty.Response); ok {
-		r0 = rf(ctx, endpoint, body, headers)
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string, ...rest.RequestOption) *resty.Response); ok {
+		r0 = rf(ctx, endpoint, body, headers, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*resty.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, map[string]string) error); ok {
-		r1 = rf(ctx, endpoint, body, headers)
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, map[string]string, ...rest.RequestOption) error); ok {
+		r1 = rf(ctx, endpoint, body, headers, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,9 +208,16 @@ func (_m *Service) Patch(ctx context.Context, endpoint string, body interface{},
 	return r0, r1
 }
 
-// Post provides a mock function with given fields: ctx, endpoint, body, headers
-func (_m *Service) Post(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error) {
-	ret := _m.Called(ctx, endpoint, body, headers)
+// Post provides a mock function with given fields: ctx, endpoint, body, headers, opts
+func (_m *Service) Post(ctx context.Context, endpoint string, body interface{}, headers map[string]string, opts ...rest.RequestOption) (*resty.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, endpoint, body, headers)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Post")
@@ -115,19 +225,93 @@ func (_m *Service) Post(ctx context.Context, endpoint string, body interface{},
 
 	var r0 *resty.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string) (*resty.Response, error)); ok {
-		return rf(ctx, endpoint, body, headers)
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string, ...rest.RequestOption) (*resty.Response, error)); ok {
+		return rf(ctx, endpoint, body, headers, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string, ...rest.RequestOption) *resty.Response); ok {
+		r0 = rf(ctx, endpoint, body, headers, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*resty.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, map[string]string, ...rest.RequestOption) error); ok {
+		r1 = rf(ctx, endpoint, body, headers, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostForm provides a mock function with given fields: ctx, endpoint, form, headers, opts
+func (_m *Service) PostForm(ctx context.Context, endpoint string, form url.Values, headers map[string]string, opts ...rest.RequestOption) (*resty.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, endpoint, form, headers)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostForm")
+	}
+
+	var r0 *resty.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, url.Values, map[string]string, ...rest.RequestOption) (*resty.Response, error)); ok {
+		return rf(ctx, endpoint, form, headers, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, url.Values, map[string]string, ...rest.RequestOption) *resty.Response); ok {
+		r0 = rf(ctx, endpoint, form, headers, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*resty.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, url.Values, map[string]string, ...rest.RequestOption) error); ok {
+		r1 = rf(ctx, endpoint, form, headers, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostMultipart provides a mock function with given fields: ctx, endpoint, fields, files, headers, opts
+func (_m *Service) PostMultipart(ctx context.Context, endpoint string, fields map[string]string, files []rest.File, headers map[string]string, opts ...rest.RequestOption) (*resty.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, endpoint, fields, files, headers)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostMultipart")
+	}
+
+	var r0 *resty.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string, []rest.File, map[string]string, ...rest.RequestOption) (*resty.Response, error)); ok {
+		return rf(ctx, endpoint, fields, files, headers, opts...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string) *resty.Response); ok {
-		r0 = rf(ctx, endpoint, body, headers)
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string, []rest.File, map[string]string, ...rest.RequestOption) *resty.Response); ok {
+		r0 = rf(ctx, endpoint, fields, files, headers, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*resty.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, map[string]string) error); ok {
-		r1 = rf(ctx, endpoint, body, headers)
+	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]string, []rest.File, map[string]string, ...rest.RequestOption) error); ok {
+		r1 = rf(ctx, endpoint, fields, files, headers, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -135,9 +319,16 @@ func (_m *Service) Post(ctx context.Context, endpoint string, body interface{},
 	return r0, r1
 }
 
-// Put provides a mock function with given fields: ctx, endpoint, body, headers
-func (_m *Service) Put(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error) {
-	ret := _m.Called(ctx, endpoint, body, headers)
+// Put provides a mock function with given fields: ctx, endpoint, body, headers, opts
+func (_m *Service) Put(ctx context.Context, endpoint string, body interface{}, headers map[string]string, opts ...rest.RequestOption) (*resty.Response, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, endpoint, body, headers)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Put")
@@ -145,19 +336,19 @@ func (_m *Service) Put(ctx context.Context, endpoint string, body interface{}, h
 
 	var r0 *resty.Response
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string) (*resty.Response, error)); ok {
-		return rf(ctx, endpoint, body, headers)
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string, ...rest.RequestOption) (*resty.Response, error)); ok {
+		return rf(ctx, endpoint, body, headers, opts...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string) *resty.Response); ok {
-		r0 = rf(ctx, endpoint, body, headers)
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, map[string]string, ...rest.RequestOption) *resty.Response); ok {
+		r0 = rf(ctx, endpoint, body, headers, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*resty.Response)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, map[string]string) error); ok {
-		r1 = rf(ctx, endpoint, body, headers)
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, map[string]string, ...rest.RequestOption) error); ok {
+		r1 = rf(ctx, endpoint, body, headers, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -165,6 +356,45 @@ func (_m *Service) Put(ctx context.Context, endpoint string, body interface{}, h
 	return r0, r1
 }
 
+// State provides a mock function with given fields: name
+func (_m *Service) State(name string) (gobreaker.State, bool) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for State")
+	}
+
+	var r0 gobreaker.State
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) (gobreaker.State, bool)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) gobreaker.State); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(gobreaker.State)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// Use provides a mock function with given fields: interceptors
+func (_m *Service) Use(interceptors ...rest.Interceptor) {
+	_va := make([]interface{}, len(interceptors))
+	for _i := range interceptors {
+		_va[_i] = interceptors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
 // WithLogging provides a mock function with given fields: enable
 func (_m *Service) WithLogging(enable bool) {
 	_m.Called(enable)
@@ -0,0 +1,59 @@
+package resttest
+
+import (
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// RecordEnv habilita el modo grabación de NewRecordingServer cuando vale "1".
+const RecordEnv = "RESTTEST_RECORD"
+
+// Fixture asocia una ruta con las respuestas a devolver. Path admite segmentos {param}
+// y Query solo exige los parámetros declarados. Las respuestas se sirven en orden y la
+// última se repite, lo que permite simular fallos seguidos de una recuperación.
+type Fixture struct {
+	Method    string            `json:"method" yaml:"method"`
+	Path      string            `json:"path" yaml:"path"`
+	Query     map[string]string `json:"query,omitempty" yaml:"query,omitempty"`
+	Responses []Response        `json:"responses" yaml:"responses"`
+}
+
+// Response es una respuesta declarativa; JSON tiene prioridad sobre Body y Delay
+// acepta cualquier valor de time.ParseDuration (p.ej. "150ms").
+type Response struct {
+	Status  int               `json:"status,omitempty" yaml:"status,omitempty"`
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body    string            `json:"body,omitempty" yaml:"body,omitempty"`
+	JSON    interface{}       `json:"json,omitempty" yaml:"json,omitempty"`
+	Delay   string            `json:"delay,omitempty" yaml:"delay,omitempty"`
+}
+
+// Request es una solicitud recibida por el servidor, disponible para aserciones.
+type Request struct {
+	Method  string
+	Path    string
+	Query   string
+	Headers map[string]string
+	Body    []byte
+}
+
+type Server struct {
+	*httptest.Server
+	mu       sync.Mutex
+	routes   []*route
+	requests []Request
+}
+
+type route struct {
+	fixture Fixture
+	calls   int
+}
+
+type recorder struct {
+	t        testing.TB
+	target   string
+	golden   string
+	mu       sync.Mutex
+	fixtures []Fixture
+}
@@ -0,0 +1,165 @@
+package resttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// skippedHeaders no se guardan en los golden files por depender de cada ejecución.
+var skippedHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Date":              true,
+	"Connection":        true,
+	"Transfer-Encoding": true,
+}
+
+// hopHeaders no se reenvían al upstream. Accept-Encoding se quita para que el cliente HTTP
+// descomprima la respuesta y el golden file guarde el cuerpo legible.
+var hopHeaders = []string{
+	"Accept-Encoding",
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// NewRecordingServer reproduce las interacciones guardadas en golden. Con RESTTEST_RECORD=1
+// reenvía las solicitudes a target y, al terminar el test, reescribe golden con lo recibido.
+// Los headers de la solicitud (p.ej. credenciales) no se guardan.
+func NewRecordingServer(t testing.TB, target, golden string) *Server {
+	t.Helper()
+	if os.Getenv(RecordEnv) != "1" {
+		return NewServerFromFile(t, golden)
+	}
+
+	rec := &recorder{t: t, target: strings.TrimSuffix(target, "/"), golden: golden}
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.proxy(w, r)
+	}))
+	t.Cleanup(func() {
+		s.Close()
+		if err := rec.save(); err != nil {
+			t.Errorf("resttest: %v", err)
+		}
+	})
+	return s
+}
+
+func (rec *recorder) proxy(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, rec.target+r.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	req.Header = r.Header.Clone()
+	for _, h := range hopHeaders {
+		req.Header.Del(h)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	recorded := recordResponse(resp, respBody)
+	rec.add(r, recorded)
+
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(respBody)
+}
+
+func recordResponse(resp *http.Response, body []byte) Response {
+	recorded := Response{Status: resp.StatusCode}
+	for k := range resp.Header {
+		if skippedHeaders[k] {
+			continue
+		}
+		if recorded.Headers == nil {
+			recorded.Headers = make(map[string]string)
+		}
+		recorded.Headers[k] = resp.Header.Get(k)
+	}
+	var decoded interface{}
+	if strings.Contains(resp.Header.Get("Content-Type"), "json") && json.Unmarshal(body, &decoded) == nil {
+		recorded.JSON = decoded
+		return recorded
+	}
+	recorded.Body = string(body)
+	return recorded
+}
+
+func (rec *recorder) add(r *http.Request, resp Response) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	query := make(map[string]string)
+	for k := range r.URL.Query() {
+		query[k] = r.URL.Query().Get(k)
+	}
+	if len(query) == 0 {
+		query = nil
+	}
+	for i, f := range rec.fixtures {
+		if f.Method == r.Method && f.Path == r.URL.Path && sameQuery(f.Query, query) {
+			rec.fixtures[i].Responses = append(rec.fixtures[i].Responses, resp)
+			return
+		}
+	}
+	rec.fixtures = append(rec.fixtures, Fixture{
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Query:     query,
+		Responses: []Response{resp},
+	})
+}
+
+func (rec *recorder) save() error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(rec.golden), 0o755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(rec.fixtures, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(rec.golden, append(b, '\n'), 0o644)
+}
+
+func sameQuery(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
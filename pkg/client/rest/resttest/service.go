@@ -0,0 +1,182 @@
+package resttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NewServer levanta un servidor httptest que responde según fixtures; se cierra al terminar el test.
+func NewServer(t testing.TB, fixtures ...Fixture) *Server {
+	t.Helper()
+	s := &Server{}
+	for _, f := range fixtures {
+		s.routes = append(s.routes, &route{fixture: f})
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// NewServerFromFile carga las fixtures desde un archivo YAML o JSON y levanta el servidor.
+func NewServerFromFile(t testing.TB, path string) *Server {
+	t.Helper()
+	fixtures, err := LoadFixtures(path)
+	if err != nil {
+		t.Fatalf("resttest: %v", err)
+	}
+	return NewServer(t, fixtures...)
+}
+
+// LoadFixtures lee fixtures desde un archivo .yaml, .yml o .json.
+func LoadFixtures(path string) ([]Fixture, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixtures: %w", err)
+	}
+	var fixtures []Fixture
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &fixtures)
+	case ".json":
+		err = json.Unmarshal(b, &fixtures)
+	default:
+		return nil, fmt.Errorf("unsupported fixtures format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding fixtures %s: %w", path, err)
+	}
+	return fixtures, nil
+}
+
+// Requests devuelve las solicitudes recibidas, en orden de llegada.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Calls cuenta las solicitudes recibidas para method y path (ya resuelto).
+func (s *Server) Calls(method, path string) int {
+	count := 0
+	for _, r := range s.Requests() {
+		if r.Method == method && r.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	headers := make(map[string]string, len(r.Header))
+	for k := range r.Header {
+		headers[k] = r.Header.Get(k)
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Query:   r.URL.RawQuery,
+		Headers: headers,
+		Body:    body,
+	})
+	resp, ok := s.next(r)
+	s.mu.Unlock()
+
+	if !ok {
+		http.Error(w, fmt.Sprintf("resttest: no fixture for %s %s", r.Method, r.URL.RequestURI()), http.StatusNotImplemented)
+		return
+	}
+	writeResponse(w, r, resp)
+}
+
+func (s *Server) next(r *http.Request) (Response, bool) {
+	for _, rt := range s.routes {
+		if !rt.matches(r) || len(rt.fixture.Responses) == 0 {
+			continue
+		}
+		i := rt.calls
+		if i >= len(rt.fixture.Responses) {
+			i = len(rt.fixture.Responses) - 1
+		}
+		rt.calls++
+		return rt.fixture.Responses[i], true
+	}
+	return Response{}, false
+}
+
+func (rt *route) matches(r *http.Request) bool {
+	if rt.fixture.Method != "" && !strings.EqualFold(rt.fixture.Method, r.Method) {
+		return false
+	}
+	if !matchPath(rt.fixture.Path, r.URL.Path) {
+		return false
+	}
+	query := r.URL.Query()
+	for k, v := range rt.fixture.Query {
+		if query.Get(k) != v {
+			return false
+		}
+	}
+	return true
+}
+
+func matchPath(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternParts) != len(pathParts) {
+		return false
+	}
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, r *http.Request, resp Response) {
+	if resp.Delay != "" {
+		if d, err := time.ParseDuration(resp.Delay); err == nil {
+			select {
+			case <-time.After(d):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+
+	body := []byte(resp.Body)
+	if resp.JSON != nil {
+		b, err := json.Marshal(resp.JSON)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("resttest: invalid json fixture: %v", err), http.StatusInternalServerError)
+			return
+		}
+		body = b
+		w.Header().Set("Content-Type", "application/json")
+	}
+	for k, v := range resp.Headers {
+		w.Header().Set(k, v)
+	}
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
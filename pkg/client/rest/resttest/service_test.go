@@ -0,0 +1,155 @@
+package resttest
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/rest"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestServerFromYAMLWithRetry(t *testing.T) {
+	server := NewServerFromFile(t, "testdata/users.yaml")
+	client := rest.NewClient(rest.Config{
+		BaseURL:          server.URL,
+		WithRetry:        true,
+		RetryCount:       2,
+		RetryWaitTime:    10 * time.Millisecond,
+		RetryMaxWaitTime: 50 * time.Millisecond,
+	}, logrus.New())
+
+	u, err := rest.GetJSON[user](context.Background(), client, "/users/{id}", nil,
+		rest.WithRequestOptions(rest.WithPathParam("id", "1")))
+
+	assert.NoError(t, err)
+	assert.Equal(t, user{ID: 1, Name: "Ana"}, u)
+	assert.Equal(t, 2, server.Calls(http.MethodGet, "/users/1"))
+}
+
+func TestServerMatchesQuery(t *testing.T) {
+	server := NewServerFromFile(t, "testdata/users.yaml")
+	client := rest.NewClient(rest.Config{BaseURL: server.URL}, logrus.New())
+
+	resp, err := client.Get(context.Background(), "/search", nil, rest.WithQueryParam("q", "ana"))
+	assert.NoError(t, err)
+	assert.Equal(t, "ana", string(resp.Body()))
+	assert.Equal(t, "1", resp.Header().Get("X-Total"))
+
+	_, err = client.Get(context.Background(), "/search", nil, rest.WithQueryParam("q", "juan"))
+	httpErr, ok := rest.AsHTTPError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotImplemented, httpErr.StatusCode)
+}
+
+func TestServerFromJSONRecordsRequests(t *testing.T) {
+	server := NewServerFromFile(t, "testdata/accounts.json")
+	client := rest.NewClient(rest.Config{BaseURL: server.URL}, logrus.New())
+
+	resp, err := client.Post(context.Background(), "/accounts", map[string]string{"owner": "ana"}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode())
+	requests := server.Requests()
+	assert.Len(t, requests, 1)
+	assert.JSONEq(t, `{"owner": "ana"}`, string(requests[0].Body))
+}
+
+func TestServerOpensCircuitBreaker(t *testing.T) {
+	server := NewServer(t, Fixture{
+		Method:    http.MethodGet,
+		Path:      "/rates",
+		Responses: []Response{{Status: http.StatusInternalServerError}},
+	})
+	client := rest.NewClient(rest.Config{BaseURL: server.URL, WithCB: true, CBMaxRequests: 1}, logrus.New())
+
+	for i := 0; i < 3; i++ {
+		_, _ = client.Get(context.Background(), "/rates", nil)
+	}
+
+	_, err := client.Get(context.Background(), "/rates", nil)
+	assert.ErrorContains(t, err, "circuit breaker open")
+	assert.Equal(t, 2, server.Calls(http.MethodGet, "/rates"))
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 7, "name": "Luz"}`))
+	}))
+	defer upstream.Close()
+	golden := filepath.Join(t.TempDir(), "golden", "users.json")
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(RecordEnv, "1")
+		server := NewRecordingServer(t, upstream.URL, golden)
+		client := rest.NewClient(rest.Config{BaseURL: server.URL}, logrus.New())
+
+		u, err := rest.GetJSON[user](context.Background(), client, "/users/7", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "Luz", u.Name)
+	})
+
+	upstream.Close()
+
+	t.Run("replay", func(t *testing.T) {
+		server := NewRecordingServer(t, upstream.URL, golden)
+		client := rest.NewClient(rest.Config{BaseURL: server.URL}, logrus.New())
+
+		u, err := rest.GetJSON[user](context.Background(), client, "/users/7", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, user{ID: 7, Name: "Luz"}, u)
+	})
+}
+
+func TestRecordGzipUpstream(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			_, _ = w.Write([]byte(`{"id": 7, "name": "Luz"}`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte(`{"id": 7, "name": "Luz"}`))
+		_ = gz.Close()
+	}))
+	defer upstream.Close()
+	golden := filepath.Join(t.TempDir(), "users.json")
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(RecordEnv, "1")
+		server := NewRecordingServer(t, upstream.URL, golden)
+		client := rest.NewClient(rest.Config{BaseURL: server.URL}, logrus.New())
+
+		u, err := rest.GetJSON[user](context.Background(), client, "/users/7", map[string]string{"Accept-Encoding": "gzip"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Luz", u.Name)
+	})
+
+	b, err := os.ReadFile(golden)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "Content-Encoding")
+	assert.Contains(t, string(b), `"name": "Luz"`)
+
+	t.Run("replay", func(t *testing.T) {
+		server := NewRecordingServer(t, upstream.URL, golden)
+		client := rest.NewClient(rest.Config{BaseURL: server.URL}, logrus.New())
+
+		u, err := rest.GetJSON[user](context.Background(), client, "/users/7", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, user{ID: 7, Name: "Luz"}, u)
+	})
+}
@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// WithPathParams reemplaza los segmentos {nombre} del endpoint por los valores escapados.
+func WithPathParams(params map[string]string) RequestOption {
+	return func(o *requestOptions) {
+		for k, v := range params {
+			o.pathParams[k] = v
+		}
+	}
+}
+
+func WithPathParam(name, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.pathParams[name] = value
+	}
+}
+
+// WithQuery agrega los parámetros de query, codificados, a la URL de la solicitud.
+func WithQuery(values url.Values) RequestOption {
+	return func(o *requestOptions) {
+		for k, v := range values {
+			o.query[k] = append(o.query[k], v...)
+		}
+	}
+}
+
+func WithQueryParam(name, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.query.Add(name, value)
+	}
+}
+
+// RouteFromContext devuelve el endpoint sin resolver (p.ej. /users/{id}) de la solicitud en curso,
+// útil para agrupar logs y métricas por ruta desde los interceptores.
+func RouteFromContext(ctx context.Context) string {
+	route, _ := ctx.Value(routeKey{}).(string)
+	return route
+}
+
+func newRequestOptions(opts []RequestOption) requestOptions {
+	o := requestOptions{
+		pathParams: map[string]string{},
+		query:      url.Values{},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (c *client) buildURL(req request) (string, error) {
+	path, err := expandPath(req.route, req.options.pathParams)
+	if err != nil {
+		return "", err
+	}
+	u := c.baseURL + path
+	if len(req.options.query) == 0 {
+		return u, nil
+	}
+	separator := "?"
+	if strings.Contains(u, "?") {
+		separator = "&"
+	}
+	return u + separator + req.options.query.Encode(), nil
+}
+
+func expandPath(route string, params map[string]string) (string, error) {
+	if !strings.Contains(route, "{") {
+		return route, nil
+	}
+	var b strings.Builder
+	rest := route
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			b.WriteString(rest)
+			return b.String(), nil
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("invalid route template %q", route)
+		}
+		name := rest[start+1 : start+end]
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("missing path param %q for route %q", name, route)
+		}
+		b.WriteString(rest[:start])
+		b.WriteString(url.PathEscape(value))
+		rest = rest[start+end+1:]
+	}
+}
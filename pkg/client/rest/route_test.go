@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathParamsAndQueryAreEscaped(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users/john%20doe%2F1/accounts", r.URL.EscapedPath())
+		assert.Equal(t, "a&b", r.URL.Query().Get("q"))
+		assert.Equal(t, []string{"1", "2"}, r.URL.Query()["page"])
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := NewClient(Config{BaseURL: ts.URL}, logrus.New())
+
+	_, err := client.Get(context.Background(), "/users/{id}/accounts", nil,
+		WithPathParam("id", "john doe/1"),
+		WithQuery(url.Values{"page": {"1", "2"}}),
+		WithQueryParam("q", "a&b"),
+	)
+	assert.NoError(t, err)
+}
+
+func TestMissingPathParam(t *testing.T) {
+	client := NewClient(Config{BaseURL: "http://localhost"}, logrus.New())
+
+	_, err := client.Get(context.Background(), "/users/{id}", nil)
+	assert.ErrorContains(t, err, `missing path param "id"`)
+}
+
+func TestQueryAppendedToExistingQueryString(t *testing.T) {
+	c := NewClient(Config{BaseURL: "http://localhost"}, logrus.New())
+
+	u, err := c.buildURL(request{route: "/search?lang=es", options: newRequestOptions([]RequestOption{WithQueryParam("q", "x y")})})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost/search?lang=es&q=x+y", u)
+}
+
+func TestRouteFromContextAndBreakerByTemplate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	var routes []string
+	interceptor := InterceptorFuncs{
+		Before: func(ctx context.Context, r *resty.Request) error {
+			routes = append(routes, RouteFromContext(ctx))
+			return nil
+		},
+	}
+	client := NewClient(Config{BaseURL: ts.URL, WithCB: true, CBPerRoute: true}, logrus.New(), WithInterceptors(interceptor))
+
+	for _, id := range []string{"1", "2"} {
+		_, err := client.Get(context.Background(), "/users/{id}", nil, WithPathParam("id", id))
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"/users/{id}", "/users/{id}"}, routes)
+	assert.Len(t, client.Breakers(), 1)
+}
@@ -4,77 +4,142 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/sony/gobreaker/v2"
+	"golang.org/x/time/rate"
 )
 
 var _ Service = (*client)(nil)
 
-func NewClient(cfg Config, l *logrus.Logger) *client {
+func NewClient(cfg Config, l *logrus.Logger, opts ...Option) *client {
 	setDefaultConfig(&cfg)
+	o := clientOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.auth == nil {
+		o.auth = newAuthProvider(cfg.Auth, o.awsConfig)
+	}
 	r := &requester{
-		httpClient: createHttpClient(cfg, l, cfg.TimeOut),
-		breaker:    createCB(cfg, l),
+		breakers: createCB(cfg, l),
+		limiter:  createLimiter(cfg),
+		inFlight: createInFlight(cfg),
 	}
+	r.httpClient = createHttpClient(cfg, l, cfg.TimeOut, o, r)
 
 	return &client{
-		baseURL:   cfg.BaseURL,
-		requester: r,
-		logger:    l,
-		logging:   cfg.EnableLogging,
+		baseURL:      cfg.BaseURL,
+		requester:    r,
+		logger:       l,
+		logging:      cfg.EnableLogging,
+		interceptors: o.interceptors,
+		cache:        createCache(cfg, o, l),
+		hedgeDelay:   cfg.HedgeDelay * time.Millisecond,
+		fallback:     o.fallback,
 	}
 }
 
-func (c *client) Get(ctx context.Context, endpoint string, headers map[string]string) (*resty.Response, error) {
-	return c.executeRequest(ctx, func(ctx context.Context) (*resty.Response, error) {
-		return c.requester.httpClient.R().
-			SetContext(ctx).
-			SetHeaders(headers).
-			Get(c.baseURL + endpoint)
+func (c *client) Get(ctx context.Context, endpoint string, headers map[string]string, opts ...RequestOption) (*resty.Response, error) {
+	return c.executeRequest(ctx, request{
+		method:  http.MethodGet,
+		route:   endpoint,
+		headers: headers,
+		options: newRequestOptions(opts),
+	})
+}
+
+func (c *client) Post(ctx context.Context, endpoint string, body interface{}, headers map[string]string, opts ...RequestOption) (*resty.Response, error) {
+	return c.executeRequest(ctx, request{
+		method:  http.MethodPost,
+		route:   endpoint,
+		headers: headers,
+		options: newRequestOptions(opts),
+		prepare: func(r *resty.Request) {
+			r.SetBody(body)
+		},
+	})
+}
+
+func (c *client) Put(ctx context.Context, endpoint string, body interface{}, headers map[string]string, opts ...RequestOption) (*resty.Response, error) {
+	return c.executeRequest(ctx, request{
+		method:  http.MethodPut,
+		route:   endpoint,
+		headers: headers,
+		options: newRequestOptions(opts),
+		prepare: func(r *resty.Request) {
+			r.SetBody(body)
+		},
 	})
 }
 
-func (c *client) Post(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error) {
-	return c.executeRequest(ctx, func(ctx context.Context) (*resty.Response, error) {
-		return c.requester.httpClient.R().
-			SetBody(body).
-			SetContext(ctx).
-			SetHeaders(headers).
-			Post(c.baseURL + endpoint)
+func (c *client) Patch(ctx context.Context, endpoint string, body interface{}, headers map[string]string, opts ...RequestOption) (*resty.Response, error) {
+	return c.executeRequest(ctx, request{
+		method:  http.MethodPatch,
+		route:   endpoint,
+		headers: headers,
+		options: newRequestOptions(opts),
+		prepare: func(r *resty.Request) {
+			r.SetBody(body)
+		},
 	})
 }
 
-func (c *client) Put(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error) {
-	return c.executeRequest(ctx, func(ctx context.Context) (*resty.Response, error) {
-		return c.requester.httpClient.R().
-			SetBody(body).
-			SetContext(ctx).
-			SetHeaders(headers).
-			Put(c.baseURL + endpoint)
+func (c *client) Delete(ctx context.Context, endpoint string, headers map[string]string, opts ...RequestOption) (*resty.Response, error) {
+	return c.executeRequest(ctx, request{
+		method:  http.MethodDelete,
+		route:   endpoint,
+		headers: headers,
+		options: newRequestOptions(opts),
 	})
 }
 
-func (c *client) Patch(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error) {
-	return c.executeRequest(ctx, func(ctx context.Context) (*resty.Response, error) {
-		return c.requester.httpClient.R().
-			SetBody(body).
-			SetContext(ctx).
-			SetHeaders(headers).
-			Patch(c.baseURL + endpoint)
+func (c *client) PostForm(ctx context.Context, endpoint string, form url.Values, headers map[string]string, opts ...RequestOption) (*resty.Response, error) {
+	return c.executeRequest(ctx, request{
+		method:  http.MethodPost,
+		route:   endpoint,
+		headers: headers,
+		options: newRequestOptions(opts),
+		prepare: func(r *resty.Request) {
+			r.SetFormDataFromValues(form)
+		},
 	})
 }
 
-func (c *client) Delete(ctx context.Context, endpoint string, headers map[string]string) (*resty.Response, error) {
-	return c.executeRequest(ctx, func(ctx context.Context) (*resty.Response, error) {
-		return c.requester.httpClient.R().
-			SetContext(ctx).
-			SetHeaders(headers).
-			Delete(c.baseURL + endpoint)
+func (c *client) PostMultipart(ctx context.Context, endpoint string, fields map[string]string, files []File, headers map[string]string, opts ...RequestOption) (*resty.Response, error) {
+	return c.executeRequest(ctx, request{
+		method:  http.MethodPost,
+		route:   endpoint,
+		headers: headers,
+		options: newRequestOptions(opts),
+		prepare: func(r *resty.Request) {
+			r.SetMultipartFormData(fields)
+			for _, f := range files {
+				r.SetMultipartField(f.Field, f.Name, f.ContentType, f.Reader)
+			}
+		},
+	})
+}
+
+func (c *client) Download(ctx context.Context, endpoint string, w io.Writer, headers map[string]string, opts ...RequestOption) (*resty.Response, error) {
+	return c.executeRequest(ctx, request{
+		method:  http.MethodGet,
+		route:   endpoint,
+		headers: headers,
+		options: newRequestOptions(opts),
+		stream:  w,
+		prepare: func(r *resty.Request) {
+			r.SetDoNotParseResponse(true)
+		},
 	})
 }
 
@@ -82,30 +147,264 @@ func (c *client) WithLogging(enable bool) {
 	c.logging = enable
 }
 
-func createHttpClient(c Config, l *logrus.Logger, timeout time.Duration) *resty.Client {
+func (c *client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return c.cache.stats()
+}
+
+func (c *client) Use(interceptors ...Interceptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
+func (c *client) Breakers() map[string]gobreaker.State {
+	if c.requester.breakers == nil {
+		return map[string]gobreaker.State{}
+	}
+	return c.requester.breakers.states()
+}
+
+func (c *client) State(name string) (gobreaker.State, bool) {
+	if c.requester.breakers == nil {
+		return gobreaker.StateClosed, false
+	}
+	return c.requester.breakers.state(name)
+}
+
+func createHttpClient(c Config, l *logrus.Logger, timeout time.Duration, o clientOptions, r *requester) *resty.Client {
 	client := resty.New()
+	if o.auth != nil {
+		client.SetTransport(&authTransport{
+			base:     client.GetClient().Transport,
+			provider: o.auth,
+		})
+	}
+	if r.limiter != nil || r.inFlight != nil {
+		client.SetTransport(&limitTransport{
+			base:      client.GetClient().Transport,
+			requester: r,
+		})
+	}
 	if timeout > 0 {
 		client.SetTimeout(timeout * time.Second)
 	}
 	if c.WithRetry {
 		client.SetRetryCount(int(c.RetryCount)).
 			SetRetryAfter(retryAfterFunc(c.RetryWaitTime*time.Millisecond, c.RetryMaxWaitTime*time.Second, l)).
-			AddRetryCondition(func(r *resty.Response, err error) bool {
-				return err != nil || r.StatusCode() >= 500
-			})
+			SetRetryResetReaders(true).
+			AddRetryCondition(retryCondition(c)).
+			AddRetryHook(closeDiscardedBody(int(c.RetryCount)))
 	}
 	return client
 }
 
-func (c *client) executeRequest(ctx context.Context, reqFunc func(ctx context.Context) (*resty.Response, error)) (*resty.Response, error) {
+func retryCondition(c Config) resty.RetryConditionFunc {
+	methods := make(map[string]struct{}, len(c.RetryMethods))
+	for _, m := range c.RetryMethods {
+		methods[strings.ToUpper(m)] = struct{}{}
+	}
+	statusCodes := make(map[int]struct{}, len(c.RetryStatusCodes))
+	for _, s := range c.RetryStatusCodes {
+		statusCodes[s] = struct{}{}
+	}
+	return func(r *resty.Response, err error) bool {
+		if limited(err) {
+			return false
+		}
+		if r == nil || r.Request == nil {
+			return err != nil
+		}
+		if _, ok := methods[strings.ToUpper(r.Request.Method)]; !ok {
+			return false
+		}
+		if err != nil {
+			return true
+		}
+		_, ok := statusCodes[r.StatusCode()]
+		return ok
+	}
+}
+
+// closeDiscardedBody libera el cuerpo de los intentos fallidos de las descargas
+// en streaming, donde resty no lo lee; el último intento se conserva para armar el error.
+func closeDiscardedBody(retryCount int) resty.OnRetryFunc {
+	return func(r *resty.Response, _ error) {
+		if r == nil || r.Request == nil || r.Request.Attempt > retryCount {
+			return
+		}
+		if body := r.RawBody(); body != nil {
+			_ = body.Close()
+		}
+	}
+}
+
+func (c *client) executeRequest(ctx context.Context, req request) (*resty.Response, error) {
+	u, err := c.buildURL(req)
+	if err != nil {
+		return nil, err
+	}
+	req.url = u
+	ctx = context.WithValue(ctx, routeKey{}, req.route)
+
+	var resp *resty.Response
+	if c.cache != nil && req.method == http.MethodGet && req.stream == nil {
+		resp, err = c.executeCached(ctx, req)
+	} else {
+		resp, err = c.execute(ctx, req)
+	}
+	if err != nil && c.fallback != nil && errors.Is(err, gobreaker.ErrOpenState) {
+		return c.runFallback(ctx, err)
+	}
+	return resp, err
+}
+
+func (c *client) execute(ctx context.Context, req request) (*resty.Response, error) {
+	reqFunc := c.requestFunc(req)
+	if c.hedgeDelay > 0 && hedgeable(req) {
+		reqFunc = c.hedge(reqFunc)
+	}
 	ctx, cancel := c.ensureContextWithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	if c.requester.breaker != nil {
-		return c.executeWithCircuitBreaker(ctx, reqFunc)
+	var resp *resty.Response
+	var err error
+	if c.requester.breakers != nil {
+		resp, err = c.executeWithCircuitBreaker(ctx, c.requester.breakers.get(req.route), reqFunc)
+	} else {
+		resp, err = c.executeWithoutCircuitBreaker(ctx, reqFunc)
+	}
+	if limited(err) {
+		c.logRateLimited(ctx, err)
 	}
+	return resp, err
+}
 
-	return c.executeWithoutCircuitBreaker(ctx, reqFunc)
+func (c *client) requestFunc(req request) func(ctx context.Context) (*resty.Response, error) {
+	c.mu.RLock()
+	interceptors := c.interceptors
+	c.mu.RUnlock()
+
+	return func(ctx context.Context) (*resty.Response, error) {
+		r := c.requester.httpClient.R().SetContext(ctx).SetHeaders(req.headers)
+		if req.prepare != nil {
+			req.prepare(r)
+		}
+		for _, i := range interceptors {
+			if err := i.BeforeRequest(r.Context(), r); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := r.Execute(req.method, req.url)
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			err = interceptors[i].AfterResponse(r.Context(), r, resp, err)
+		}
+		if req.stream != nil && resp != nil {
+			if err == nil {
+				resp, err = copyStream(resp, req.stream)
+			} else if body := resp.RawBody(); body != nil {
+				_ = body.Close()
+			}
+		}
+		if err == nil && req.cached != nil && resp != nil && resp.StatusCode() == http.StatusNotModified {
+			resp = req.cached.revalidated(r, resp)
+		}
+		return resp, err
+	}
+}
+
+func copyStream(resp *resty.Response, w io.Writer) (*resty.Response, error) {
+	body := resp.RawBody()
+	if body == nil {
+		return resp, nil
+	}
+	defer body.Close()
+	if !resp.IsSuccess() {
+		b, err := io.ReadAll(io.LimitReader(body, maxErrorBodySize))
+		if err != nil {
+			return nil, err
+		}
+		return resp.SetBody(b), nil
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return nil, fmt.Errorf("error streaming response body: %w", err)
+	}
+	return resp, nil
+}
+
+// RoundTrip aplica el rate limit y MaxInFlight a cada intento, incluidos los reintentos de resty;
+// el slot en vuelo se libera al cerrar el cuerpo de la respuesta.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// acquire usa el permiso tomado por el hedge si lo hay; los reintentos vuelven a esperar.
+func (t *limitTransport) acquire(ctx context.Context) (func(), error) {
+	if p, ok := permitFromContext(ctx); ok {
+		if release, ok := p.take(); ok {
+			return release, nil
+		}
+	}
+	return t.requester.acquire(ctx)
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+func (r *requester) acquire(ctx context.Context) (func(), error) {
+	if r.limiter != nil {
+		if err := r.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrRateLimitExceeded, err)
+		}
+	}
+	if r.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case r.inFlight <- struct{}{}:
+		return func() { <-r.inFlight }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: %v", ErrMaxInFlightExceeded, ctx.Err())
+	}
+}
+
+// tryAcquire toma un token y un slot en vuelo sin esperar; devuelve false si alguno no está disponible.
+func (r *requester) tryAcquire() (func(), bool) {
+	release := func() {}
+	if r.inFlight != nil {
+		select {
+		case r.inFlight <- struct{}{}:
+			release = func() { <-r.inFlight }
+		default:
+			return nil, false
+		}
+	}
+	if r.limiter != nil && !r.limiter.Allow() {
+		release()
+		return nil, false
+	}
+	return release, true
+}
+
+// limited indica que la solicitud no salió por los límites locales; no se reintenta
+// ni cuenta como falla del Circuit Breaker.
+func limited(err error) bool {
+	return errors.Is(err, ErrRateLimitExceeded) || errors.Is(err, ErrMaxInFlightExceeded)
 }
 
 func (c *client) ensureContextWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
@@ -117,8 +416,8 @@ func (c *client) ensureContextWithTimeout(ctx context.Context, timeout time.Dura
 	return context.WithTimeout(ctx, timeout)
 }
 
-func (c *client) executeWithCircuitBreaker(ctx context.Context, reqFunc func(ctx context.Context) (*resty.Response, error)) (*resty.Response, error) {
-	result, err := c.requester.breaker.Execute(func() (interface{}, error) {
+func (c *client) executeWithCircuitBreaker(ctx context.Context, breaker *gobreaker.CircuitBreaker[any], reqFunc func(ctx context.Context) (*resty.Response, error)) (*resty.Response, error) {
+	result, err := breaker.Execute(func() (interface{}, error) {
 		return c.performRequest(ctx, reqFunc)
 	})
 
@@ -169,6 +468,7 @@ func (c *client) logRequestFailure(ctx context.Context, err error) {
 	if c.logging {
 		c.logger.Warn(ctx, "failed", map[string]interface{}{
 			"event": "request_failed",
+			"route": RouteFromContext(ctx),
 			"error": err,
 		})
 	}
@@ -178,12 +478,22 @@ func (c *client) logHttpError(ctx context.Context, resp *resty.Response, err err
 	if c.logging {
 		c.logger.Warn(ctx, "http_error", map[string]interface{}{
 			"event":  "http_error",
+			"route":  RouteFromContext(ctx),
 			"status": resp.StatusCode(),
 			"error":  err,
 		})
 	}
 }
 
+func (c *client) logRateLimited(ctx context.Context, err error) {
+	if c.logging {
+		c.logger.Warn(ctx, "rate_limited", map[string]interface{}{
+			"event": "rate_limited",
+			"error": err,
+		})
+	}
+}
+
 func (c *client) logCircuitBreakerOpen(ctx context.Context, err error) {
 	if c.logging {
 		c.logger.Error(ctx, err, "circuit breaker is open", map[string]interface{}{
@@ -200,15 +510,7 @@ func validateResponse(resp *resty.Response) error {
 	if resp.StatusCode() >= 200 && resp.StatusCode() <= 299 {
 		return nil
 	}
-	bodyPreview := ""
-	if resp.Body() != nil && len(resp.Body()) > 0 {
-		text := string(resp.Body())
-		if len(text) > 200 {
-			text = text[:200] + "..."
-		}
-		bodyPreview = text
-	}
-	return fmt.Errorf("HTTP %d: %s - %s", resp.StatusCode(), resp.Status(), bodyPreview)
+	return newHTTPError(resp)
 }
 
 func setDefaultConfig(cfg *Config) {
@@ -232,6 +534,13 @@ func setDefaultConfig(cfg *Config) {
 		}
 	}
 
+	if len(cfg.RetryStatusCodes) == 0 {
+		cfg.RetryStatusCodes = DefaultRetryStatusCodes
+	}
+	if len(cfg.RetryMethods) == 0 {
+		cfg.RetryMethods = DefaultRetryMethods
+	}
+
 	defaultsDuration := map[*time.Duration]time.Duration{
 		&cfg.RetryWaitTime:    DefaultRetryWaitTime,
 		&cfg.RetryMaxWaitTime: DefaultRetryMaxWaitTime,
@@ -245,32 +554,111 @@ func setDefaultConfig(cfg *Config) {
 	}
 }
 
-func createCB(c Config, l *logrus.Logger) *gobreaker.CircuitBreaker[any] {
+func createLimiter(c Config) *rate.Limiter {
+	if c.RateLimit <= 0 {
+		return nil
+	}
+	burst := c.RateBurst
+	if burst <= 0 {
+		burst = int(math.Max(1, math.Ceil(c.RateLimit)))
+	}
+	return rate.NewLimiter(rate.Limit(c.RateLimit), burst)
+}
+
+func createInFlight(c Config) chan struct{} {
+	if c.MaxInFlight <= 0 {
+		return nil
+	}
+	return make(chan struct{}, c.MaxInFlight)
+}
+
+func createCB(c Config, l *logrus.Logger) *breakerRegistry {
 	if !c.WithCB {
 		return nil
 	}
-	cbConfig := gobreaker.Settings{
-		Name:        c.CBName,
-		MaxRequests: c.CBMaxRequests,
-		Interval:    c.CBInterval * time.Second,
-		Timeout:     c.CBTimeout * time.Second,
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			return checkBreakerState(counts, c, l)
+	r := &breakerRegistry{
+		name:     c.CBName,
+		perRoute: c.CBPerRoute,
+		breakers: make(map[string]*gobreaker.CircuitBreaker[any]),
+		settings: func(name string) gobreaker.Settings {
+			return gobreaker.Settings{
+				Name:        name,
+				MaxRequests: c.CBMaxRequests,
+				Interval:    c.CBInterval * time.Second,
+				Timeout:     c.CBTimeout * time.Second,
+				ReadyToTrip: func(counts gobreaker.Counts) bool {
+					return checkBreakerState(counts, c, l)
+				},
+				IsSuccessful: func(err error) bool {
+					return err == nil || limited(err)
+				},
+
+				OnStateChange: func(name string, from, to gobreaker.State) {
+					l.Warn("Circuit Breaker state changed", map[string]interface{}{
+						"client": c.CBName,
+						"name":   name,
+						"from":   from,
+						"to":     to,
+					})
+				},
+			}
 		},
+	}
+	if !r.perRoute {
+		r.get("")
+	}
+	return r
+}
 
-		OnStateChange: func(name string, from, to gobreaker.State) {
-			l.Warn("Circuit Breaker state changed", map[string]interface{}{
-				"name": name,
-				"from": from,
-				"to":   to,
-			})
-		},
+func (r *breakerRegistry) get(route string) *gobreaker.CircuitBreaker[any] {
+	name := r.breakerName(route)
+	r.mu.RLock()
+	cb, ok := r.breakers[name]
+	r.mu.RUnlock()
+	if ok {
+		return cb
 	}
 
-	cb := gobreaker.NewCircuitBreaker[any](cbConfig)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cb, ok := r.breakers[name]; ok {
+		return cb
+	}
+	cb = gobreaker.NewCircuitBreaker[any](r.settings(name))
+	r.breakers[name] = cb
 	return cb
 }
 
+func (r *breakerRegistry) breakerName(route string) string {
+	if !r.perRoute {
+		return r.name
+	}
+	if i := strings.IndexByte(route, '?'); i >= 0 {
+		route = route[:i]
+	}
+	return route
+}
+
+func (r *breakerRegistry) state(name string) (gobreaker.State, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cb, ok := r.breakers[name]
+	if !ok {
+		return gobreaker.StateClosed, false
+	}
+	return cb.State(), true
+}
+
+func (r *breakerRegistry) states() map[string]gobreaker.State {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	states := make(map[string]gobreaker.State, len(r.breakers))
+	for name, cb := range r.breakers {
+		states[name] = cb.State()
+	}
+	return states
+}
+
 func checkBreakerState(counts gobreaker.Counts, c Config, l *logrus.Logger) bool {
 	var failureRate float64
 	if counts.Requests > 0 {
@@ -294,11 +682,38 @@ func checkBreakerState(counts gobreaker.Counts, c Config, l *logrus.Logger) bool
 
 func retryAfterFunc(initialWaitTime, maxWaitTime time.Duration, l *logrus.Logger) func(*resty.Client, *resty.Response) (time.Duration, error) {
 	return func(client *resty.Client, resp *resty.Response) (time.Duration, error) {
+		if wait, ok := retryAfterHeader(resp); ok {
+			if wait > maxWaitTime {
+				wait = maxWaitTime
+			}
+			return wait, nil
+		}
 		attempt := resp.Request.Attempt
 		return exponentialBackoffWithJitter(initialWaitTime, maxWaitTime, attempt, l), nil
 	}
 }
 
+func retryAfterHeader(resp *resty.Response) (time.Duration, bool) {
+	if resp == nil || resp.RawResponse == nil {
+		return 0, false
+	}
+	value := resp.Header().Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
 func exponentialBackoffWithJitter(initialWaitTime, maxWaitTime time.Duration, attempt int, l *logrus.Logger) time.Duration {
 	if attempt <= 0 {
 		attempt = 1
@@ -1,15 +1,20 @@
 package rest
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 
 	"github.com/sirupsen/logrus"
+	"github.com/sony/gobreaker/v2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -227,4 +232,273 @@ func TestSetDefaultConfig(t *testing.T) {
 	assert.Equal(t, DefaultCBTimeout, cfg.CBTimeout)
 	assert.Equal(t, DefaultCBRequestThreshold, cfg.CBRequestThreshold)
 	assert.Equal(t, DefaultCBFailureRateLimit, cfg.CBFailureRateLimit)
+	assert.Equal(t, DefaultRetryStatusCodes, cfg.RetryStatusCodes)
+	assert.Equal(t, DefaultRetryMethods, cfg.RetryMethods)
+}
+
+func TestRetryNotAppliedToPost(t *testing.T) {
+	attempts := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "Temporary error", http.StatusInternalServerError)
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New())
+	_, err := client.Post(context.Background(), ts.URL, map[string]string{"name": "test"}, mockHeaders)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryOnTooManyRequestsWithRetryAfter(t *testing.T) {
+	attempts := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New())
+	resp, err := client.Get(context.Background(), ts.URL, mockHeaders)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryAfterFuncCapsHeader(t *testing.T) {
+	resp := &resty.Response{
+		Request:     &resty.Request{Attempt: 1},
+		RawResponse: &http.Response{Header: http.Header{"Retry-After": []string{"120"}}},
+	}
+
+	wait, err := retryAfterFunc(100*time.Millisecond, 2*time.Second, logrus.New())(nil, resp)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Second, wait)
+}
+
+func TestRetryAfterHeaderHTTPDate(t *testing.T) {
+	resp := &resty.Response{
+		RawResponse: &http.Response{Header: http.Header{
+			"Retry-After": []string{time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)},
+		}},
+	}
+
+	wait, ok := retryAfterHeader(resp)
+
+	assert.True(t, ok)
+	assert.LessOrEqual(t, wait, 10*time.Second)
+	assert.Greater(t, wait, 8*time.Second)
+}
+
+func TestPerRouteCircuitBreaker(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			http.Error(w, "Server error", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	cfg := mockConfigWithCB
+	cfg.BaseURL = ts.URL
+	cfg.CBMaxRequests = 1
+	cfg.CBPerRoute = true
+	client := NewClient(cfg, logrus.New())
+
+	for i := 0; i < 3; i++ {
+		_, _ = client.Get(context.Background(), "/fail", mockHeaders)
+	}
+	resp, err := client.Get(context.Background(), "/ok?page=1", mockHeaders)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+
+	state, ok := client.State("/fail")
+	assert.True(t, ok)
+	assert.Equal(t, gobreaker.StateOpen, state)
+
+	state, ok = client.State("/ok")
+	assert.True(t, ok)
+	assert.Equal(t, gobreaker.StateClosed, state)
+	assert.Len(t, client.Breakers(), 2)
+}
+
+func TestSharedCircuitBreakerState(t *testing.T) {
+	client := NewClient(mockConfigWithCB, logrus.New())
+
+	state, ok := client.State(mockConfigWithCB.CBName)
+	assert.True(t, ok)
+	assert.Equal(t, gobreaker.StateClosed, state)
+
+	_, ok = NewClient(mockConfigWithRetry, logrus.New()).State("any")
+	assert.False(t, ok)
+}
+
+func TestRateLimitFailsFastOnDeadline(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithRetry
+	cfg.RateLimit = 1
+	cfg.RateBurst = 1
+	client := NewClient(cfg, logrus.New())
+
+	_, err := client.Get(context.Background(), ts.URL, mockHeaders)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Get(ctx, ts.URL, mockHeaders)
+
+	assert.ErrorIs(t, err, ErrRateLimitExceeded)
+}
+
+func TestRateLimitAppliesToRetries(t *testing.T) {
+	var hits []time.Time
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits = append(hits, time.Now())
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithRetry
+	cfg.RetryWaitTime = 10
+	cfg.RetryMaxWaitTime = 1
+	cfg.RateLimit = 5
+	cfg.RateBurst = 1
+	client := NewClient(cfg, logrus.New())
+
+	_, err := client.Get(context.Background(), ts.URL, mockHeaders)
+
+	assert.Error(t, err)
+	assert.Len(t, hits, 3)
+	for i := 1; i < len(hits); i++ {
+		assert.GreaterOrEqual(t, hits[i].Sub(hits[i-1]), 150*time.Millisecond)
+	}
+}
+
+func TestMaxInFlight(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	cfg := mockConfigWithRetry
+	cfg.MaxInFlight = 1
+	client := NewClient(cfg, logrus.New())
+
+	done := make(chan error)
+	go func() {
+		_, err := client.Get(context.Background(), ts.URL, mockHeaders)
+		done <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Get(ctx, ts.URL, mockHeaders)
+	assert.ErrorIs(t, err, ErrMaxInFlightExceeded)
+
+	close(release)
+	assert.NoError(t, <-done)
+}
+
+func TestPostFormRequest(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "a b&c", r.PostForm.Get("q"))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New())
+	resp, err := client.PostForm(context.Background(), ts.URL, url.Values{"q": {"a b&c"}}, mockHeaders)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+}
+
+func TestPostMultipartRequest(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, "2024-01", r.FormValue("period"))
+		f, header, err := r.FormFile("statement")
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(f)
+		assert.Equal(t, "statement.pdf", header.Filename)
+		assert.Equal(t, "application/pdf", header.Header.Get("Content-Type"))
+		assert.Equal(t, "%PDF-1.4", string(content))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New())
+	resp, err := client.PostMultipart(context.Background(), ts.URL,
+		map[string]string{"period": "2024-01"},
+		[]File{{Field: "statement", Name: "statement.pdf", ContentType: "application/pdf", Reader: strings.NewReader("%PDF-1.4")}},
+		nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode())
+}
+
+func TestDownloadStreamsBody(t *testing.T) {
+	content := strings.Repeat("report-line\n", 1000)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(content))
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New())
+	var buf bytes.Buffer
+	resp, err := client.Download(context.Background(), ts.URL, &buf, mockHeaders)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, content, buf.String())
+}
+
+func TestDownloadErrorKeepsBody(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "report not found", http.StatusNotFound)
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithCB, logrus.New())
+	var buf bytes.Buffer
+	_, err := client.Download(context.Background(), ts.URL, &buf, mockHeaders)
+
+	httpErr, ok := AsHTTPError(err)
+	assert.True(t, ok)
+	assert.Equal(t, "report not found\n", string(httpErr.Body))
+	assert.Empty(t, buf.String())
 }
@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const contentTypeJSON = "application/json"
+
+// GetJSON ejecuta un GET y decodifica el cuerpo de la respuesta en T.
+func GetJSON[T any](ctx context.Context, s Service, endpoint string, headers map[string]string, opts ...JSONOption) (T, error) {
+	resp, err := s.Get(ctx, endpoint, jsonHeaders(headers, false), requestOptionsOf(opts)...)
+	return decodeJSON[T](resp, err, opts)
+}
+
+// PostJSON envía body serializado como JSON y decodifica la respuesta en Resp.
+func PostJSON[Req, Resp any](ctx context.Context, s Service, endpoint string, body Req, headers map[string]string, opts ...JSONOption) (Resp, error) {
+	resp, err := s.Post(ctx, endpoint, body, jsonHeaders(headers, true), requestOptionsOf(opts)...)
+	return decodeJSON[Resp](resp, err, opts)
+}
+
+// PutJSON envía body serializado como JSON y decodifica la respuesta en Resp.
+func PutJSON[Req, Resp any](ctx context.Context, s Service, endpoint string, body Req, headers map[string]string, opts ...JSONOption) (Resp, error) {
+	resp, err := s.Put(ctx, endpoint, body, jsonHeaders(headers, true), requestOptionsOf(opts)...)
+	return decodeJSON[Resp](resp, err, opts)
+}
+
+// PatchJSON envía body serializado como JSON y decodifica la respuesta en Resp.
+func PatchJSON[Req, Resp any](ctx context.Context, s Service, endpoint string, body Req, headers map[string]string, opts ...JSONOption) (Resp, error) {
+	resp, err := s.Patch(ctx, endpoint, body, jsonHeaders(headers, true), requestOptionsOf(opts)...)
+	return decodeJSON[Resp](resp, err, opts)
+}
+
+// DeleteJSON ejecuta un DELETE y decodifica el cuerpo de la respuesta en T.
+func DeleteJSON[T any](ctx context.Context, s Service, endpoint string, headers map[string]string, opts ...JSONOption) (T, error) {
+	resp, err := s.Delete(ctx, endpoint, jsonHeaders(headers, false), requestOptionsOf(opts)...)
+	return decodeJSON[T](resp, err, opts)
+}
+
+// WithErrorBody decodifica el cuerpo de las respuestas no 2xx en target,
+// que debe ser un puntero al tipo de error esperado del servicio remoto.
+func WithErrorBody(target interface{}) JSONOption {
+	return func(o *jsonOptions) {
+		o.errorBody = target
+	}
+}
+
+// WithRequestOptions aplica opciones de solicitud (path params, query) a los helpers JSON.
+func WithRequestOptions(opts ...RequestOption) JSONOption {
+	return func(o *jsonOptions) {
+		o.requestOptions = append(o.requestOptions, opts...)
+	}
+}
+
+func requestOptionsOf(opts []JSONOption) []RequestOption {
+	o := jsonOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.requestOptions
+}
+
+func decodeJSON[T any](resp *resty.Response, err error, opts []JSONOption) (T, error) {
+	var out T
+	if err != nil {
+		decodeErrorBody(err, opts)
+		return out, err
+	}
+	if resp == nil || len(resp.Body()) == 0 {
+		return out, nil
+	}
+	if err := json.Unmarshal(resp.Body(), &out); err != nil {
+		return out, fmt.Errorf("error decoding response body: %w", err)
+	}
+	return out, nil
+}
+
+func decodeErrorBody(err error, opts []JSONOption) {
+	o := jsonOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.errorBody == nil {
+		return
+	}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || len(httpErr.Body) == 0 {
+		return
+	}
+	_ = json.Unmarshal(httpErr.Body, o.errorBody)
+}
+
+func jsonHeaders(headers map[string]string, withBody bool) map[string]string {
+	h := make(map[string]string, len(headers)+2)
+	for k, v := range headers {
+		h[http.CanonicalHeaderKey(k)] = v
+	}
+	if _, ok := h["Accept"]; !ok {
+		h["Accept"] = contentTypeJSON
+	}
+	if _, ok := h["Content-Type"]; withBody && !ok {
+		h["Content-Type"] = contentTypeJSON
+	}
+	return h
+}
@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type apiError struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+func TestGetJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": "1", "name": "John"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New())
+	u, err := GetJSON[user](context.Background(), client, ts.URL, mockHeaders)
+
+	assert.NoError(t, err)
+	assert.Equal(t, user{ID: "1", Name: "John"}, u)
+}
+
+func TestPostJSON(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in user
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		in.ID = "2"
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(in)
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New())
+	u, err := PostJSON[user, user](context.Background(), client, ts.URL, user{Name: "Jane"}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, user{ID: "2", Name: "Jane"}, u)
+}
+
+func TestGetJSONErrorBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": "ERR-404", "msg": "not found"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New())
+	var apiErr apiError
+	u, err := GetJSON[user](context.Background(), client, ts.URL, nil, WithErrorBody(&apiErr))
+
+	assert.Error(t, err)
+	assert.Empty(t, u)
+	assert.Equal(t, apiError{Code: "ERR-404", Msg: "not found"}, apiErr)
+}
+
+func TestGetJSONInvalidBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`not-json`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(mockConfigWithRetry, logrus.New())
+	_, err := GetJSON[user](context.Background(), client, ts.URL, nil)
+
+	assert.ErrorContains(t, err, "error decoding response body")
+}
@@ -2,14 +2,72 @@ package sns
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/internal/messaging"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
 )
 
+const (
+	AttributeEventType     = "event_type"
+	AttributeCorrelationID = "correlation_id"
+	DefaultPublishRetries  = 3
+	MaxBatchEntries        = messaging.MaxBatchEntries
+	MaxBatchBytes          = messaging.MaxBatchBytes
+	throttleBackoff        = 100 * time.Millisecond
+	fifoSuffix             = ".fifo"
+)
+
+var ErrMessageTooLarge = errors.New("sns message exceeds 256KB")
+
+// Config admite alias de tópicos, p.ej. topics: {transfers: arn:aws:sns:...:transfers}.
 type Config struct {
-	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	Endpoint   string            `json:"endpoint" yaml:"endpoint"`
+	Topics     map[string]string `json:"topics" yaml:"topics"`
+	MaxRetries int               `json:"max_retries" yaml:"max_retries"`
 }
 
 type Service interface {
 	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+	PublishBatch(ctx context.Context, params *sns.PublishBatchInput, optFns ...func(*sns.Options)) (*sns.PublishBatchOutput, error)
+}
+
+// Event es un mensaje a publicar. Payload se serializa a JSON salvo que sea string o []byte.
+// En tópicos FIFO se exige GroupID y, si DeduplicationID está vacío, se usa el SHA256 del mensaje.
+type Event struct {
+	Payload         interface{}
+	EventType       string
+	CorrelationID   string
+	Subject         string
+	Attributes      map[string]string
+	GroupID         string
+	DeduplicationID string
+}
+
+// BatchError reúne los eventos que no se pudieron publicar luego de los reintentos.
+type BatchError = messaging.BatchError
+
+type BatchFailure = messaging.BatchFailure
+
+type Dependencies struct {
+	Client *Sns
+	Log    log.Service
+}
+
+type Publisher struct {
+	client     Service
+	log        log.Service
+	topics     map[string]string
+	maxRetries int
+}
+
+type correlationKey struct{}
+
+type publishEntry struct {
+	index int
+	entry types.PublishBatchRequestEntry
+	size  int
 }
@@ -0,0 +1,248 @@
+package sns
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/smithy-go"
+	"github.com/uala-challenge/simple-toolkit/pkg/internal/messaging"
+)
+
+func NewPublisher(d Dependencies, cfg Config) *Publisher {
+	topics := make(map[string]string, len(cfg.Topics))
+	for alias, arn := range cfg.Topics {
+		topics[strings.ToLower(alias)] = arn
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = DefaultPublishRetries
+	}
+	return &Publisher{
+		client:     d.Client.Cliente,
+		log:        d.Log,
+		topics:     topics,
+		maxRetries: cfg.MaxRetries,
+	}
+}
+
+// ContextWithCorrelationID guarda el correlation id que se agrega a los eventos publicados con ctx.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
+
+// Publish publica un evento en el tópico indicado por alias (sección sns.topics) o ARN.
+func (p *Publisher) Publish(ctx context.Context, topic string, e Event) (string, error) {
+	arn, err := p.topicArn(topic)
+	if err != nil {
+		return "", err
+	}
+	entry, err := p.entry(ctx, arn, 0, e)
+	if err != nil {
+		return "", err
+	}
+	input := &sns.PublishInput{
+		TopicArn:               aws.String(arn),
+		Message:                entry.entry.Message,
+		Subject:                entry.entry.Subject,
+		MessageAttributes:      entry.entry.MessageAttributes,
+		MessageGroupId:         entry.entry.MessageGroupId,
+		MessageDeduplicationId: entry.entry.MessageDeduplicationId,
+	}
+
+	for attempt := 0; ; attempt++ {
+		out, err := p.client.Publish(ctx, input)
+		if err == nil {
+			return aws.ToString(out.MessageId), nil
+		}
+		if !isThrottling(err) || attempt >= p.maxRetries {
+			return "", p.log.WrapError(err, "error publicando evento en sns")
+		}
+		if !messaging.Backoff(ctx, throttleBackoff, attempt) {
+			return "", ctx.Err()
+		}
+	}
+}
+
+// PublishBatch publica los eventos en lotes de hasta 10 entradas y 256KB, reintentando los
+// errores de throttling y las entradas que fallaron por errores del servicio. Si un lote falla
+// por otro error, el *BatchError incluye además los eventos no publicados y envuelve la causa.
+func (p *Publisher) PublishBatch(ctx context.Context, topic string, events []Event) error {
+	arn, err := p.topicArn(topic)
+	if err != nil {
+		return err
+	}
+	entries := make([]publishEntry, 0, len(events))
+	for i, e := range events {
+		entry, err := p.entry(ctx, arn, i, e)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	batchErr := messaging.NewBatchError("sns")
+	chunks := messaging.Chunk(entries, func(e publishEntry) int { return e.size })
+	for i, chunk := range chunks {
+		failed, err := p.publishChunk(ctx, arn, chunk)
+		batchErr.Failed = append(batchErr.Failed, failed...)
+		if err != nil {
+			for _, unpublished := range chunks[i+1:] {
+				batchErr.Failed = append(batchErr.Failed, messaging.Unsent(unpublished, publishIndex, err)...)
+			}
+			batchErr.Err = err
+			return batchErr
+		}
+	}
+	if len(batchErr.Failed) > 0 {
+		return batchErr
+	}
+	return nil
+}
+
+func (p *Publisher) publishChunk(ctx context.Context, arn string, pending []publishEntry) ([]BatchFailure, error) {
+	for i := range pending {
+		pending[i].entry.Id = aws.String(strconv.Itoa(i))
+	}
+	var failures []BatchFailure
+	for attempt := 0; ; attempt++ {
+		byID := make(map[string]publishEntry, len(pending))
+		input := &sns.PublishBatchInput{TopicArn: aws.String(arn)}
+		for _, e := range pending {
+			byID[aws.ToString(e.entry.Id)] = e
+			input.PublishBatchRequestEntries = append(input.PublishBatchRequestEntries, e.entry)
+		}
+
+		out, err := p.client.PublishBatch(ctx, input)
+		if err != nil {
+			if !isThrottling(err) || attempt >= p.maxRetries {
+				err = p.log.WrapError(err, "error publicando lote en sns")
+				return append(failures, messaging.Unsent(pending, publishIndex, err)...), err
+			}
+			if !messaging.Backoff(ctx, throttleBackoff, attempt) {
+				return append(failures, messaging.Unsent(pending, publishIndex, ctx.Err())...), ctx.Err()
+			}
+			continue
+		}
+
+		var retry []publishEntry
+		var retryFailures []BatchFailure
+		for _, f := range out.Failed {
+			e := byID[aws.ToString(f.Id)]
+			failure := BatchFailure{Index: e.index, Code: aws.ToString(f.Code), Message: aws.ToString(f.Message)}
+			if f.SenderFault {
+				failures = append(failures, failure)
+				continue
+			}
+			retry = append(retry, e)
+			retryFailures = append(retryFailures, failure)
+		}
+		if len(retry) == 0 {
+			return failures, nil
+		}
+		if attempt >= p.maxRetries {
+			return append(failures, retryFailures...), nil
+		}
+		if !messaging.Backoff(ctx, throttleBackoff, attempt) {
+			return append(failures, retryFailures...), ctx.Err()
+		}
+		pending = retry
+	}
+}
+
+func publishIndex(e publishEntry) int {
+	return e.index
+}
+
+func (p *Publisher) topicArn(topic string) (string, error) {
+	if strings.HasPrefix(topic, "arn:") {
+		return topic, nil
+	}
+	arn, ok := p.topics[strings.ToLower(topic)]
+	if !ok {
+		return "", fmt.Errorf("sns topic %q not configured", topic)
+	}
+	return arn, nil
+}
+
+func (p *Publisher) entry(ctx context.Context, arn string, index int, e Event) (publishEntry, error) {
+	message, err := messaging.MarshalPayload(e.Payload)
+	if err != nil {
+		return publishEntry{}, p.log.WrapError(err, "error serializando evento")
+	}
+	attributes := make(map[string]types.MessageAttributeValue, len(e.Attributes)+2)
+	for k, v := range e.Attributes {
+		attributes[k] = stringAttribute(v)
+	}
+	if e.EventType != "" {
+		attributes[AttributeEventType] = stringAttribute(e.EventType)
+	}
+	correlationID := e.CorrelationID
+	if correlationID == "" {
+		correlationID = CorrelationIDFromContext(ctx)
+	}
+	if correlationID != "" {
+		attributes[AttributeCorrelationID] = stringAttribute(correlationID)
+	}
+
+	size := len(message)
+	for k, v := range attributes {
+		size += len(k) + len(aws.ToString(v.DataType)) + len(aws.ToString(v.StringValue))
+	}
+	if size > MaxBatchBytes {
+		return publishEntry{}, fmt.Errorf("%w: event %d has %d bytes", ErrMessageTooLarge, index, size)
+	}
+
+	entry := types.PublishBatchRequestEntry{
+		Message: aws.String(message),
+	}
+	if e.Subject != "" {
+		entry.Subject = aws.String(e.Subject)
+	}
+	if len(attributes) > 0 {
+		entry.MessageAttributes = attributes
+	}
+	if strings.HasSuffix(arn, fifoSuffix) {
+		if e.GroupID == "" {
+			return publishEntry{}, fmt.Errorf("event %d: fifo topic requires a message group id", index)
+		}
+		dedupID := e.DeduplicationID
+		if dedupID == "" {
+			hash := sha256.Sum256([]byte(message))
+			dedupID = hex.EncodeToString(hash[:])
+		}
+		entry.MessageGroupId = aws.String(e.GroupID)
+		entry.MessageDeduplicationId = aws.String(dedupID)
+	}
+	return publishEntry{index: index, entry: entry, size: size}, nil
+}
+
+func isThrottling(err error) bool {
+	var throttled *types.ThrottledException
+	if errors.As(err, &throttled) {
+		return true
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "Throttled", "Throttling", "ThrottlingException", "TooManyRequestsException":
+			return true
+		}
+	}
+	return false
+}
+
+func stringAttribute(v string) types.MessageAttributeValue {
+	return types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(v)}
+}
@@ -0,0 +1,137 @@
+package sns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/sns/mock"
+	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+const transfersArn = "arn:aws:sns:us-east-1:123456789012:transfers"
+
+type transfer struct {
+	ID     string `json:"id"`
+	Amount int    `json:"amount"`
+}
+
+func newPublisher(client *cl.Service) *Publisher {
+	logger := &log.Service{}
+	logger.On("WrapError", mock.Anything, mock.Anything).Return(func(err error, msg string) error {
+		return fmt.Errorf("%s: %w", msg, err)
+	}).Maybe()
+	return NewPublisher(Dependencies{Client: &Sns{Cliente: client}, Log: logger}, Config{
+		Topics: map[string]string{
+			"Transfers":      transfersArn,
+			"transfers-fifo": transfersArn + ".fifo",
+		},
+	})
+}
+
+func TestPublishResolvesAliasAndAttributes(t *testing.T) {
+	client := &cl.Service{}
+	client.On("Publish", mock.Anything, mock.MatchedBy(func(in *sns.PublishInput) bool {
+		return aws.ToString(in.TopicArn) == transfersArn &&
+			aws.ToString(in.Message) == `{"id":"t-1","amount":10}` &&
+			aws.ToString(in.MessageAttributes[AttributeEventType].StringValue) == "transfer_created" &&
+			aws.ToString(in.MessageAttributes[AttributeCorrelationID].StringValue) == "corr-1" &&
+			aws.ToString(in.MessageAttributes["currency"].StringValue) == "ARS"
+	})).Return(&sns.PublishOutput{MessageId: aws.String("m-1")}, nil)
+
+	ctx := ContextWithCorrelationID(context.Background(), "corr-1")
+	id, err := newPublisher(client).Publish(ctx, "transfers", Event{
+		Payload:    transfer{ID: "t-1", Amount: 10},
+		EventType:  "transfer_created",
+		Attributes: map[string]string{"currency": "ARS"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "m-1", id)
+}
+
+func TestPublishUnknownTopic(t *testing.T) {
+	_, err := newPublisher(&cl.Service{}).Publish(context.Background(), "payments", Event{Payload: "x"})
+	assert.ErrorContains(t, err, `sns topic "payments" not configured`)
+}
+
+func TestPublishRetriesThrottling(t *testing.T) {
+	client := &cl.Service{}
+	client.On("Publish", mock.Anything, mock.Anything).Return(nil, &types.ThrottledException{Message: aws.String("slow down")}).Once()
+	client.On("Publish", mock.Anything, mock.Anything).Return(&sns.PublishOutput{MessageId: aws.String("m-2")}, nil).Once()
+
+	id, err := newPublisher(client).Publish(context.Background(), transfersArn, Event{Payload: "x"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "m-2", id)
+	client.AssertNumberOfCalls(t, "Publish", 2)
+}
+
+func TestPublishFIFO(t *testing.T) {
+	client := &cl.Service{}
+	client.On("Publish", mock.Anything, mock.MatchedBy(func(in *sns.PublishInput) bool {
+		return aws.ToString(in.MessageGroupId) == "account-1" && aws.ToString(in.MessageDeduplicationId) == "dedup-1"
+	})).Return(&sns.PublishOutput{MessageId: aws.String("m-3")}, nil)
+	publisher := newPublisher(client)
+
+	_, err := publisher.Publish(context.Background(), "transfers-fifo", Event{Payload: "x"})
+	assert.ErrorContains(t, err, "message group id")
+
+	_, err = publisher.Publish(context.Background(), "transfers-fifo", Event{Payload: "x", GroupID: "account-1", DeduplicationID: "dedup-1"})
+	assert.NoError(t, err)
+}
+
+func TestPublishBatchRetriesFailedEntries(t *testing.T) {
+	client := &cl.Service{}
+	var sizes []int
+	client.On("PublishBatch", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			sizes = append(sizes, len(args.Get(1).(*sns.PublishBatchInput).PublishBatchRequestEntries))
+		}).
+		Return(&sns.PublishBatchOutput{Failed: []types.BatchResultErrorEntry{
+			{Id: aws.String("0"), Code: aws.String("InternalError")},
+		}}, nil).Once()
+	client.On("PublishBatch", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			sizes = append(sizes, len(args.Get(1).(*sns.PublishBatchInput).PublishBatchRequestEntries))
+		}).
+		Return(&sns.PublishBatchOutput{}, nil)
+
+	events := make([]Event, 12)
+	for i := range events {
+		events[i] = Event{Payload: transfer{Amount: i}, EventType: "transfer_created"}
+	}
+
+	err := newPublisher(client).PublishBatch(context.Background(), "transfers", events)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10, 1, 2}, sizes)
+}
+
+func TestPublishBatchReportsUnpublishedChunks(t *testing.T) {
+	cause := errors.New("connection reset")
+	client := &cl.Service{}
+	client.On("PublishBatch", mock.Anything, mock.Anything).Return(&sns.PublishBatchOutput{}, nil).Once()
+	client.On("PublishBatch", mock.Anything, mock.Anything).Return(nil, cause).Once()
+
+	events := make([]Event, 12)
+	for i := range events {
+		events[i] = Event{Payload: transfer{Amount: i}}
+	}
+
+	err := newPublisher(client).PublishBatch(context.Background(), "transfers", events)
+
+	var batchErr *BatchError
+	assert.ErrorAs(t, err, &batchErr)
+	assert.ErrorIs(t, err, cause)
+	assert.Len(t, batchErr.Failed, 2)
+	assert.Equal(t, 10, batchErr.Failed[0].Index)
+	assert.Equal(t, 11, batchErr.Failed[1].Index)
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,209 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/internal/messaging"
+)
+
+func NewConsumer(d Dependencies, cfg ConsumerConfig, h Handler) *Consumer {
+	setDefaultConsumerConfig(&cfg)
+	return &Consumer{
+		client:  d.Client.Cliente,
+		log:     d.Log,
+		cfg:     cfg,
+		handler: h,
+	}
+}
+
+// Start consume la cola hasta que se cancele ctx. Al cancelarse deja de recibir mensajes y espera
+// a los handlers en curso hasta DrainTimeout; pasado ese tiempo cancela su contexto.
+func (c *Consumer) Start(ctx context.Context) error {
+	if c.cfg.QueueURL == "" {
+		return errors.New("sqs consumer requires a queue url")
+	}
+	handlerCtx, cancelHandlers := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelHandlers()
+
+	slots := make(chan struct{}, c.cfg.Concurrency)
+	for {
+		n, err := c.acquire(ctx, slots)
+		if err != nil {
+			break
+		}
+		messages, err := c.receive(ctx, n)
+		for i := len(messages); i < n; i++ {
+			<-slots
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			c.log.Error(ctx, err, "error recibiendo mensajes de sqs", map[string]interface{}{"queue": c.cfg.QueueURL})
+			if !messaging.Sleep(ctx, receiveErrorBackoff) {
+				break
+			}
+			continue
+		}
+		for _, msg := range messages {
+			c.wg.Add(1)
+			go func(msg types.Message) {
+				defer func() {
+					<-slots
+					c.wg.Done()
+				}()
+				c.process(handlerCtx, msg)
+			}(msg)
+		}
+	}
+
+	c.drain(cancelHandlers)
+	return nil
+}
+
+// acquire bloquea hasta tener un worker libre y toma los que estén disponibles, hasta MaxMessages.
+func (c *Consumer) acquire(ctx context.Context, slots chan struct{}) (int, error) {
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+	n := 1
+	for n < int(c.cfg.MaxMessages) {
+		select {
+		case slots <- struct{}{}:
+			n++
+		default:
+			return n, nil
+		}
+	}
+	return n, nil
+}
+
+func (c *Consumer) receive(ctx context.Context, n int) ([]types.Message, error) {
+	out, err := c.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:                    aws.String(c.cfg.QueueURL),
+		MaxNumberOfMessages:         int32(n),
+		WaitTimeSeconds:             c.cfg.WaitTime,
+		VisibilityTimeout:           c.cfg.VisibilityTimeout,
+		MessageAttributeNames:       []string{"All"},
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameAll},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Messages, nil
+}
+
+func (c *Consumer) process(ctx context.Context, msg types.Message) {
+	fields := map[string]interface{}{
+		"queue":      c.cfg.QueueURL,
+		"message_id": aws.ToString(msg.MessageId),
+	}
+	stop := c.heartbeat(ctx, msg)
+	err := c.handle(ctx, msg)
+	stop()
+	if err != nil {
+		c.log.Error(ctx, err, "error procesando mensaje de sqs", fields)
+		if !c.shouldDeadLetter(msg, err) {
+			return
+		}
+		if err := c.deadLetter(ctx, msg, err); err != nil {
+			c.log.Error(ctx, err, "error moviendo mensaje a la dlq", fields)
+			return
+		}
+	}
+	_, err = c.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(c.cfg.QueueURL),
+		ReceiptHandle: msg.ReceiptHandle,
+	})
+	if err != nil {
+		c.log.Error(ctx, err, "error eliminando mensaje de sqs", fields)
+	}
+}
+
+func (c *Consumer) handle(ctx context.Context, msg types.Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic processing message: %v", r)
+		}
+	}()
+	return c.handler(ctx, msg)
+}
+
+// heartbeat extiende la visibilidad del mensaje mientras el handler sigue corriendo.
+func (c *Consumer) heartbeat(ctx context.Context, msg types.Message) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(time.Duration(c.cfg.HeartbeatInterval) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_, err := c.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+					QueueUrl:          aws.String(c.cfg.QueueURL),
+					ReceiptHandle:     msg.ReceiptHandle,
+					VisibilityTimeout: c.cfg.VisibilityTimeout,
+				})
+				if err != nil {
+					c.log.Warn(ctx, "error extendiendo visibilidad del mensaje", map[string]interface{}{
+						"queue":      c.cfg.QueueURL,
+						"message_id": aws.ToString(msg.MessageId),
+						"error":      err,
+					})
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func (c *Consumer) drain(cancelHandlers context.CancelFunc) {
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Duration(c.cfg.DrainTimeout) * time.Second):
+		cancelHandlers()
+		<-done
+	}
+}
+
+func setDefaultConsumerConfig(cfg *ConsumerConfig) {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConcurrency
+	}
+	if cfg.MaxMessages <= 0 || cfg.MaxMessages > DefaultMaxMessages {
+		cfg.MaxMessages = DefaultMaxMessages
+	}
+	if cfg.WaitTime <= 0 || cfg.WaitTime > DefaultWaitTime {
+		cfg.WaitTime = DefaultWaitTime
+	}
+	if cfg.VisibilityTimeout <= 0 {
+		cfg.VisibilityTimeout = DefaultVisibility
+	}
+	if cfg.HeartbeatInterval <= 0 || cfg.HeartbeatInterval >= cfg.VisibilityTimeout {
+		cfg.HeartbeatInterval = max(cfg.VisibilityTimeout/2, 1)
+	}
+	if cfg.DrainTimeout <= 0 {
+		cfg.DrainTimeout = DefaultDrainTimeout
+	}
+}
@@ -0,0 +1,109 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/sqs/mock"
+	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+func mockReceive(client *cl.Service, messages ...types.Message) {
+	client.On("ReceiveMessage", mock.Anything, mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{Messages: messages}, nil).Once()
+	client.On("ReceiveMessage", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(nil, context.Canceled)
+}
+
+func message(id string) types.Message {
+	return types.Message{MessageId: aws.String(id), ReceiptHandle: aws.String("rh-" + id), Body: aws.String("{}")}
+}
+
+func TestConsumerDeletesOnlyProcessedMessages(t *testing.T) {
+	client := &cl.Service{}
+	logger := &log.Service{}
+	logger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	mockReceive(client, message("ok"), message("fail"))
+	client.On("DeleteMessage", mock.Anything, mock.MatchedBy(func(in *sqs.DeleteMessageInput) bool {
+		return aws.ToString(in.ReceiptHandle) == "rh-ok"
+	})).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+
+	var handled atomic.Int32
+	consumer := NewConsumer(Dependencies{Client: &Sqs{Cliente: client}, Log: logger},
+		ConsumerConfig{QueueURL: "queue", Concurrency: 2},
+		func(ctx context.Context, msg types.Message) error {
+			handled.Add(1)
+			if aws.ToString(msg.MessageId) == "fail" {
+				return errors.New("boom")
+			}
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return handled.Load() == 2 }, time.Second, 10*time.Millisecond)
+		cancel()
+	}()
+
+	assert.NoError(t, consumer.Start(ctx))
+	client.AssertNumberOfCalls(t, "DeleteMessage", 1)
+	client.AssertExpectations(t)
+}
+
+func TestConsumerExtendsVisibilityAndDrains(t *testing.T) {
+	client := &cl.Service{}
+	logger := &log.Service{}
+	mockReceive(client, message("slow"))
+	client.On("ChangeMessageVisibility", mock.Anything, mock.MatchedBy(func(in *sqs.ChangeMessageVisibilityInput) bool {
+		return aws.ToString(in.ReceiptHandle) == "rh-slow" && in.VisibilityTimeout == 2
+	})).Return(&sqs.ChangeMessageVisibilityOutput{}, nil)
+	client.On("DeleteMessage", mock.Anything, mock.Anything).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+
+	started := make(chan struct{})
+	consumer := NewConsumer(Dependencies{Client: &Sqs{Cliente: client}, Log: logger},
+		ConsumerConfig{QueueURL: "queue", VisibilityTimeout: 2},
+		func(ctx context.Context, msg types.Message) error {
+			close(started)
+			time.Sleep(1200 * time.Millisecond)
+			return ctx.Err()
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	assert.NoError(t, consumer.Start(ctx))
+	client.AssertCalled(t, "ChangeMessageVisibility", mock.Anything, mock.Anything)
+	client.AssertNumberOfCalls(t, "DeleteMessage", 1)
+}
+
+func TestConsumerRequiresQueueURL(t *testing.T) {
+	consumer := NewConsumer(Dependencies{Client: &Sqs{Cliente: &cl.Service{}}, Log: &log.Service{}},
+		ConsumerConfig{}, func(context.Context, types.Message) error { return nil })
+
+	assert.Error(t, consumer.Start(context.Background()))
+}
+
+func TestSetDefaultConsumerConfig(t *testing.T) {
+	cfg := ConsumerConfig{MaxMessages: 50, VisibilityTimeout: 60}
+	setDefaultConsumerConfig(&cfg)
+
+	assert.Equal(t, DefaultConcurrency, cfg.Concurrency)
+	assert.Equal(t, DefaultMaxMessages, cfg.MaxMessages)
+	assert.Equal(t, DefaultWaitTime, cfg.WaitTime)
+	assert.Equal(t, int32(30), cfg.HeartbeatInterval)
+	assert.Equal(t, DefaultDrainTimeout, cfg.DrainTimeout)
+}
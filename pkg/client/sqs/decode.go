@@ -0,0 +1,88 @@
+package sqs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const snsNotification = "Notification"
+
+// Decode decodifica el cuerpo JSON del mensaje en T. Si el mensaje es una notificación SNS
+// sin raw delivery, primero desenvuelve el sobre. Los errores de formato son *MalformedMessageError.
+func Decode[T any](msg types.Message) (Decoded[T], error) {
+	d := Decoded[T]{
+		MessageID:    aws.ToString(msg.MessageId),
+		Attributes:   messageAttributes(msg),
+		ReceiveCount: ReceiveCount(msg),
+		Raw:          msg,
+	}
+	if msg.Body == nil {
+		return d, &MalformedMessageError{MessageID: d.MessageID, Reason: "empty body"}
+	}
+
+	body := aws.ToString(msg.Body)
+	if envelope, ok := parseSNSEnvelope(body); ok {
+		body = envelope.Message
+		d.TopicArn = envelope.TopicArn
+		for k, v := range envelope.MessageAttributes {
+			d.Attributes[k] = v.Value
+		}
+	}
+
+	if s, ok := any(&d.Payload).(*string); ok {
+		*s = body
+		return d, nil
+	}
+	if err := json.Unmarshal([]byte(body), &d.Payload); err != nil {
+		return d, &MalformedMessageError{MessageID: d.MessageID, Reason: "invalid json payload", Err: err}
+	}
+	return d, nil
+}
+
+// ReceiveCount devuelve ApproximateReceiveCount; es 0 si no se pidió el atributo al recibir.
+func ReceiveCount(msg types.Message) int {
+	n, _ := strconv.Atoi(msg.Attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+	return n
+}
+
+// IsMalformed indica si err (o alguno de los errores que envuelve) es un *MalformedMessageError.
+func IsMalformed(err error) bool {
+	var malformed *MalformedMessageError
+	return errors.As(err, &malformed)
+}
+
+func parseSNSEnvelope(body string) (snsEnvelope, bool) {
+	var envelope snsEnvelope
+	if err := json.Unmarshal([]byte(body), &envelope); err != nil {
+		return envelope, false
+	}
+	return envelope, envelope.Type == snsNotification && envelope.TopicArn != ""
+}
+
+func messageAttributes(msg types.Message) map[string]string {
+	attributes := make(map[string]string, len(msg.MessageAttributes))
+	for k, v := range msg.MessageAttributes {
+		if v.StringValue != nil {
+			attributes[k] = aws.ToString(v.StringValue)
+		} else if v.BinaryValue != nil {
+			attributes[k] = string(v.BinaryValue)
+		}
+	}
+	return attributes
+}
+
+func (e *MalformedMessageError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("malformed sqs message %s: %s: %v", e.MessageID, e.Reason, e.Err)
+	}
+	return fmt.Sprintf("malformed sqs message %s: %s", e.MessageID, e.Reason)
+}
+
+func (e *MalformedMessageError) Unwrap() error {
+	return e.Err
+}
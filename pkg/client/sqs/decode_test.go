@@ -0,0 +1,70 @@
+package sqs
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeRawMessage(t *testing.T) {
+	msg := types.Message{
+		MessageId: aws.String("m-1"),
+		Body:      aws.String(`{"id":"t-1","amount":10}`),
+		Attributes: map[string]string{
+			string(types.MessageSystemAttributeNameApproximateReceiveCount): "3",
+		},
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			"event_type": {DataType: aws.String("String"), StringValue: aws.String("transfer_created")},
+		},
+	}
+
+	d, err := Decode[transfer](msg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, transfer{ID: "t-1", Amount: 10}, d.Payload)
+	assert.Equal(t, "m-1", d.MessageID)
+	assert.Equal(t, 3, d.ReceiveCount)
+	assert.Equal(t, "transfer_created", d.Attributes["event_type"])
+	assert.Empty(t, d.TopicArn)
+}
+
+func TestDecodeSNSEnvelope(t *testing.T) {
+	msg := types.Message{
+		MessageId: aws.String("m-2"),
+		Body: aws.String(`{
+			"Type": "Notification",
+			"MessageId": "sns-1",
+			"TopicArn": "arn:aws:sns:us-east-1:123456789012:transfers",
+			"Message": "{\"id\":\"t-2\",\"amount\":5}",
+			"MessageAttributes": {"event_type": {"Type": "String", "Value": "transfer_sent"}}
+		}`),
+	}
+
+	d, err := Decode[transfer](msg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, transfer{ID: "t-2", Amount: 5}, d.Payload)
+	assert.Equal(t, "arn:aws:sns:us-east-1:123456789012:transfers", d.TopicArn)
+	assert.Equal(t, "transfer_sent", d.Attributes["event_type"])
+}
+
+func TestDecodeString(t *testing.T) {
+	d, err := Decode[string](types.Message{Body: aws.String("plain text")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "plain text", d.Payload)
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	_, err := Decode[transfer](types.Message{MessageId: aws.String("m-3"), Body: aws.String("not json")})
+
+	var malformed *MalformedMessageError
+	assert.ErrorAs(t, err, &malformed)
+	assert.Equal(t, "m-3", malformed.MessageID)
+	assert.True(t, IsMalformed(err))
+
+	_, err = Decode[transfer](types.Message{MessageId: aws.String("m-4")})
+	assert.True(t, IsMalformed(err))
+}
@@ -0,0 +1,143 @@
+package sqs
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// shouldDeadLetter mueve a la DLQ los mensajes que alcanzaron MaxReceiveCount y los mal formados,
+// que no se van a poder procesar en ningún reintento.
+func (c *Consumer) shouldDeadLetter(msg types.Message, err error) bool {
+	if c.cfg.DLQURL == "" {
+		return false
+	}
+	if IsMalformed(err) {
+		return true
+	}
+	return c.cfg.MaxReceiveCount > 0 && ReceiveCount(msg) >= c.cfg.MaxReceiveCount
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, msg types.Message, cause error) error {
+	errorMessage := cause.Error()
+	if len(errorMessage) > maxErrorAttributeLength {
+		errorMessage = errorMessage[:maxErrorAttributeLength]
+	}
+	attributes := map[string]types.MessageAttributeValue{
+		AttributeError:        stringAttribute(errorMessage),
+		AttributeSourceQueue:  stringAttribute(c.cfg.QueueURL),
+		AttributeReceiveCount: {DataType: aws.String("Number"), StringValue: aws.String(strconv.Itoa(ReceiveCount(msg)))},
+		AttributeFailedAt:     stringAttribute(time.Now().UTC().Format(time.RFC3339)),
+	}
+	for k, v := range msg.MessageAttributes {
+		if len(attributes) >= maxMessageAttributes {
+			break
+		}
+		attributes[k] = v
+	}
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:          aws.String(c.cfg.DLQURL),
+		MessageBody:       msg.Body,
+		MessageAttributes: attributes,
+	}
+	setFIFOFields(input, msg)
+	_, err := c.client.SendMessage(ctx, input)
+	return err
+}
+
+// Redrive devuelve los mensajes de la DLQ a la cola de origen, sin los atributos agregados por
+// el consumidor, y los borra de la DLQ. Como SQS no garantiza devolver mensajes en cada recepción,
+// termina tras varias recepciones seguidas sin mensajes nuevos o al alcanzar MaxMessages.
+// Los mensajes descartados por Filter vuelven a ser visibles y se cuentan una sola vez.
+func Redrive(ctx context.Context, client Service, cfg RedriveConfig) (RedriveResult, error) {
+	result := RedriveResult{}
+	skipped := make(map[string]struct{})
+	for empty := 0; empty < redriveEmptyReceives; {
+		if cfg.MaxMessages > 0 && result.Moved+result.Skipped >= cfg.MaxMessages {
+			break
+		}
+		out, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:                    aws.String(cfg.DLQURL),
+			MaxNumberOfMessages:         DefaultMaxMessages,
+			VisibilityTimeout:           redriveVisibility,
+			WaitTimeSeconds:             redriveWaitTime,
+			MessageAttributeNames:       []string{"All"},
+			MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameAll},
+		})
+		if err != nil {
+			return result, err
+		}
+		progress := false
+		for _, msg := range out.Messages {
+			if cfg.MaxMessages > 0 && result.Moved+result.Skipped >= cfg.MaxMessages {
+				break
+			}
+			id := aws.ToString(msg.MessageId)
+			if _, ok := skipped[id]; ok {
+				continue
+			}
+			progress = true
+			if cfg.Filter != nil && !cfg.Filter(msg) {
+				skipped[id] = struct{}{}
+				result.Skipped++
+				continue
+			}
+			if err := redriveMessage(ctx, client, cfg, msg); err != nil {
+				return result, err
+			}
+			result.Moved++
+		}
+		if progress {
+			empty = 0
+		} else {
+			empty++
+		}
+	}
+	return result, nil
+}
+
+func redriveMessage(ctx context.Context, client Service, cfg RedriveConfig, msg types.Message) error {
+	attributes := make(map[string]types.MessageAttributeValue, len(msg.MessageAttributes))
+	for k, v := range msg.MessageAttributes {
+		if !strings.HasPrefix(k, "dlq.") {
+			attributes[k] = v
+		}
+	}
+	input := &sqs.SendMessageInput{
+		QueueUrl:          aws.String(cfg.TargetURL),
+		MessageBody:       msg.Body,
+		MessageAttributes: attributes,
+	}
+	setFIFOFields(input, msg)
+	if _, err := client.SendMessage(ctx, input); err != nil {
+		return err
+	}
+	_, err := client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(cfg.DLQURL),
+		ReceiptHandle: msg.ReceiptHandle,
+	})
+	return err
+}
+
+// setFIFOFields conserva el grupo del mensaje original cuando el destino es una cola FIFO.
+func setFIFOFields(input *sqs.SendMessageInput, msg types.Message) {
+	if !strings.HasSuffix(aws.ToString(input.QueueUrl), fifoSuffix) {
+		return
+	}
+	groupID := msg.Attributes[string(types.MessageSystemAttributeNameMessageGroupId)]
+	if groupID == "" {
+		groupID = aws.ToString(msg.MessageId)
+	}
+	input.MessageGroupId = aws.String(groupID)
+	input.MessageDeduplicationId = msg.MessageId
+}
+
+func stringAttribute(v string) types.MessageAttributeValue {
+	return types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(v)}
+}
@@ -0,0 +1,141 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/sqs/mock"
+	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+func TestConsumerMovesToDLQAfterMaxReceiveCount(t *testing.T) {
+	client := &cl.Service{}
+	logger := &log.Service{}
+	logger.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	exhausted := message("exhausted")
+	exhausted.Attributes = map[string]string{string(types.MessageSystemAttributeNameApproximateReceiveCount): "5"}
+	retry := message("retry")
+	retry.Attributes = map[string]string{string(types.MessageSystemAttributeNameApproximateReceiveCount): "1"}
+	mockReceive(client, exhausted, retry)
+
+	client.On("SendMessage", mock.Anything, mock.MatchedBy(func(in *sqs.SendMessageInput) bool {
+		return aws.ToString(in.QueueUrl) == "dlq" &&
+			aws.ToString(in.MessageAttributes[AttributeError].StringValue) == "boom" &&
+			aws.ToString(in.MessageAttributes[AttributeSourceQueue].StringValue) == "queue" &&
+			aws.ToString(in.MessageAttributes[AttributeReceiveCount].StringValue) == "5"
+	})).Return(&sqs.SendMessageOutput{}, nil).Once()
+	client.On("DeleteMessage", mock.Anything, mock.MatchedBy(func(in *sqs.DeleteMessageInput) bool {
+		return aws.ToString(in.ReceiptHandle) == "rh-exhausted"
+	})).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+
+	var handled atomic.Int32
+	consumer := NewConsumer(Dependencies{Client: &Sqs{Cliente: client}, Log: logger},
+		ConsumerConfig{QueueURL: "queue", DLQURL: "dlq", MaxReceiveCount: 5},
+		func(ctx context.Context, msg types.Message) error {
+			handled.Add(1)
+			return errors.New("boom")
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return handled.Load() == 2 }, time.Second, 10*time.Millisecond)
+		cancel()
+	}()
+
+	assert.NoError(t, consumer.Start(ctx))
+	client.AssertExpectations(t)
+	client.AssertNumberOfCalls(t, "DeleteMessage", 1)
+}
+
+func TestShouldDeadLetterMalformed(t *testing.T) {
+	consumer := NewConsumer(Dependencies{Client: &Sqs{Cliente: &cl.Service{}}, Log: &log.Service{}},
+		ConsumerConfig{QueueURL: "queue", DLQURL: "dlq"}, nil)
+
+	assert.True(t, consumer.shouldDeadLetter(message("m"), &MalformedMessageError{Reason: "invalid json payload"}))
+	assert.False(t, consumer.shouldDeadLetter(message("m"), errors.New("timeout")))
+}
+
+func TestRedriveWithFilter(t *testing.T) {
+	client := &cl.Service{}
+	keep := message("keep")
+	keep.MessageAttributes = map[string]types.MessageAttributeValue{"event_type": stringAttribute("other")}
+	move := message("move")
+	move.MessageAttributes = map[string]types.MessageAttributeValue{
+		"event_type":   stringAttribute("transfer_created"),
+		AttributeError: stringAttribute("boom"),
+	}
+	client.On("ReceiveMessage", mock.Anything, mock.MatchedBy(func(in *sqs.ReceiveMessageInput) bool {
+		return in.WaitTimeSeconds >= 1
+	})).Return(&sqs.ReceiveMessageOutput{Messages: []types.Message{keep, move}}, nil).Once()
+	client.On("ReceiveMessage", mock.Anything, mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{}, nil).Times(redriveEmptyReceives)
+	client.On("SendMessage", mock.Anything, mock.MatchedBy(func(in *sqs.SendMessageInput) bool {
+		_, hasError := in.MessageAttributes[AttributeError]
+		return aws.ToString(in.QueueUrl) == "queue" && !hasError && len(in.MessageAttributes) == 1
+	})).Return(&sqs.SendMessageOutput{}, nil).Once()
+	client.On("DeleteMessage", mock.Anything, mock.MatchedBy(func(in *sqs.DeleteMessageInput) bool {
+		return aws.ToString(in.QueueUrl) == "dlq" && aws.ToString(in.ReceiptHandle) == "rh-move"
+	})).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+
+	result, err := Redrive(context.Background(), client, RedriveConfig{
+		DLQURL:    "dlq",
+		TargetURL: "queue",
+		Filter: func(msg types.Message) bool {
+			return aws.ToString(msg.MessageAttributes["event_type"].StringValue) == "transfer_created"
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, RedriveResult{Moved: 1, Skipped: 1}, result)
+	client.AssertExpectations(t)
+}
+
+func TestRedriveContinuesAfterEmptyReceive(t *testing.T) {
+	client := &cl.Service{}
+	client.On("ReceiveMessage", mock.Anything, mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{}, nil).Once()
+	client.On("ReceiveMessage", mock.Anything, mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{Messages: []types.Message{message("late")}}, nil).Once()
+	client.On("ReceiveMessage", mock.Anything, mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{}, nil).Times(redriveEmptyReceives)
+	client.On("SendMessage", mock.Anything, mock.Anything).Return(&sqs.SendMessageOutput{}, nil).Once()
+	client.On("DeleteMessage", mock.Anything, mock.Anything).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+
+	result, err := Redrive(context.Background(), client, RedriveConfig{DLQURL: "dlq", TargetURL: "queue"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, RedriveResult{Moved: 1}, result)
+	client.AssertExpectations(t)
+}
+
+func TestRedriveCountsSkippedMessagesOnce(t *testing.T) {
+	client := &cl.Service{}
+	client.On("ReceiveMessage", mock.Anything, mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{Messages: []types.Message{message("keep")}}, nil).Times(2)
+	client.On("ReceiveMessage", mock.Anything, mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{Messages: []types.Message{message("keep"), message("move")}}, nil).Once()
+	client.On("ReceiveMessage", mock.Anything, mock.Anything).
+		Return(&sqs.ReceiveMessageOutput{}, nil)
+	client.On("SendMessage", mock.Anything, mock.Anything).Return(&sqs.SendMessageOutput{}, nil).Once()
+	client.On("DeleteMessage", mock.Anything, mock.Anything).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+
+	result, err := Redrive(context.Background(), client, RedriveConfig{
+		DLQURL:      "dlq",
+		TargetURL:   "queue",
+		MaxMessages: 2,
+		Filter:      func(msg types.Message) bool { return aws.ToString(msg.MessageId) == "move" },
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, RedriveResult{Moved: 1, Skipped: 1}, result)
+	client.AssertExpectations(t)
+}
@@ -2,10 +2,45 @@ package sqs
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/internal/messaging"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
 )
 
+const (
+	DefaultConcurrency            = 5
+	DefaultMaxMessages      int32 = 10
+	DefaultWaitTime         int32 = 20
+	DefaultVisibility       int32 = 30
+	DefaultDrainTimeout     int32 = 30
+	DefaultSendRetries            = 3
+	MaxBatchEntries               = messaging.MaxBatchEntries
+	MaxBatchBytes                 = messaging.MaxBatchBytes
+	receiveErrorBackoff           = time.Second
+	sendRetryBackoff              = 100 * time.Millisecond
+	fifoSuffix                    = ".fifo"
+	maxMessageAttributes          = 10
+	maxErrorAttributeLength       = 1024
+	redriveVisibility       int32 = 30
+	redriveWaitTime         int32 = 1
+	redriveEmptyReceives          = 3
+)
+
+// Atributos que se agregan a los mensajes enviados a la DLQ.
+const (
+	AttributeError        = "dlq.error"
+	AttributeSourceQueue  = "dlq.source_queue"
+	AttributeReceiveCount = "dlq.receive_count"
+	AttributeFailedAt     = "dlq.failed_at"
+)
+
+var ErrMessageTooLarge = errors.New("sqs message exceeds 256KB")
+
 type Config struct {
 	Endpoint string `json:"endpoint" yaml:"endpoint"`
 }
@@ -13,4 +48,119 @@ type Config struct {
 type Service interface {
 	ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
 	DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error)
+	SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+	SendMessageBatch(ctx context.Context, params *sqs.SendMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error)
+	ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error)
+}
+
+// Handler procesa un mensaje; si devuelve error el mensaje no se borra y vuelve a la cola
+// cuando vence su visibilidad.
+type Handler func(ctx context.Context, msg types.Message) error
+
+// ConsumerConfig se declara en la sección processors de la configuración.
+// Los tiempos se expresan en segundos.
+type ConsumerConfig struct {
+	QueueURL          string `json:"queue_url" yaml:"queue_url"`
+	Concurrency       int    `json:"concurrency" yaml:"concurrency"`
+	MaxMessages       int32  `json:"max_messages" yaml:"max_messages"`
+	WaitTime          int32  `json:"wait_time" yaml:"wait_time"`
+	VisibilityTimeout int32  `json:"visibility_timeout" yaml:"visibility_timeout"`
+	HeartbeatInterval int32  `json:"heartbeat_interval" yaml:"heartbeat_interval"`
+	DrainTimeout      int32  `json:"drain_timeout" yaml:"drain_timeout"`
+	DLQURL            string `json:"dlq_url" yaml:"dlq_url"`
+	MaxReceiveCount   int    `json:"max_receive_count" yaml:"max_receive_count"`
+}
+
+// RedriveConfig indica desde qué DLQ y hacia qué cola se devuelven los mensajes.
+// Filter decide qué mensajes se mueven; los demás quedan en la DLQ.
+type RedriveConfig struct {
+	DLQURL      string
+	TargetURL   string
+	MaxMessages int
+	Filter      func(msg types.Message) bool
+}
+
+type RedriveResult struct {
+	Moved   int
+	Skipped int
+}
+
+// ProducerConfig configura el envío a una cola; si la URL termina en .fifo se exige MessageGroupId.
+type ProducerConfig struct {
+	QueueURL       string `json:"queue_url" yaml:"queue_url"`
+	DefaultGroupID string `json:"default_group_id" yaml:"default_group_id"`
+	MaxRetries     int    `json:"max_retries" yaml:"max_retries"`
+}
+
+// Message es un mensaje a enviar. Body se serializa a JSON salvo que sea string o []byte.
+// En colas FIFO, si DeduplicationID está vacío se usa el SHA256 del cuerpo.
+type Message struct {
+	Body            interface{}
+	GroupID         string
+	DeduplicationID string
+	Attributes      map[string]string
+	DelaySeconds    int32
+}
+
+// BatchError reúne los mensajes que no se pudieron enviar luego de los reintentos.
+type BatchError = messaging.BatchError
+
+type BatchFailure = messaging.BatchFailure
+
+// Decoded es el resultado de Decode: el payload tipado más los metadatos del mensaje.
+// Si el mensaje llegó envuelto por SNS, Attributes y TopicArn salen del sobre.
+type Decoded[T any] struct {
+	Payload      T
+	MessageID    string
+	Attributes   map[string]string
+	ReceiveCount int
+	TopicArn     string
+	Raw          types.Message
+}
+
+// MalformedMessageError indica que el cuerpo del mensaje no se pudo decodificar;
+// reintentarlo no va a cambiar el resultado.
+type MalformedMessageError struct {
+	MessageID string
+	Reason    string
+	Err       error
+}
+
+type snsEnvelope struct {
+	Type              string                       `json:"Type"`
+	MessageID         string                       `json:"MessageId"`
+	TopicArn          string                       `json:"TopicArn"`
+	Message           string                       `json:"Message"`
+	MessageAttributes map[string]snsAttributeValue `json:"MessageAttributes"`
+}
+
+type snsAttributeValue struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+type Dependencies struct {
+	Client *Sqs
+	Log    log.Service
+}
+
+type Consumer struct {
+	client  Service
+	log     log.Service
+	cfg     ConsumerConfig
+	handler Handler
+	wg      sync.WaitGroup
+}
+
+type Producer struct {
+	client Service
+	log    log.Service
+	cfg    ProducerConfig
+	fifo   bool
+}
+
+type batchEntry struct {
+	index int
+	entry types.SendMessageBatchRequestEntry
+	size  int
 }
@@ -0,0 +1,167 @@
+package sqs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/internal/messaging"
+)
+
+func NewProducer(d Dependencies, cfg ProducerConfig) *Producer {
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = DefaultSendRetries
+	}
+	return &Producer{
+		client: d.Client.Cliente,
+		log:    d.Log,
+		cfg:    cfg,
+		fifo:   strings.HasSuffix(cfg.QueueURL, fifoSuffix),
+	}
+}
+
+// Send envía un único mensaje y devuelve su MessageId.
+func (p *Producer) Send(ctx context.Context, msg Message) (string, error) {
+	e, err := p.entry(0, msg)
+	if err != nil {
+		return "", err
+	}
+	out, err := p.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:               aws.String(p.cfg.QueueURL),
+		MessageBody:            e.entry.MessageBody,
+		MessageAttributes:      e.entry.MessageAttributes,
+		MessageGroupId:         e.entry.MessageGroupId,
+		MessageDeduplicationId: e.entry.MessageDeduplicationId,
+		DelaySeconds:           e.entry.DelaySeconds,
+	})
+	if err != nil {
+		return "", p.log.WrapError(err, "error enviando mensaje a sqs")
+	}
+	return aws.ToString(out.MessageId), nil
+}
+
+// SendBatch agrupa los mensajes en lotes de hasta 10 entradas y 256KB y reintenta las entradas
+// que fallaron por errores del servicio. Las que siguen fallando se devuelven en un *BatchError;
+// si un lote falla por un error de transporte, el *BatchError incluye además los mensajes no
+// enviados y envuelve la causa.
+func (p *Producer) SendBatch(ctx context.Context, messages []Message) error {
+	entries := make([]batchEntry, 0, len(messages))
+	for i, msg := range messages {
+		e, err := p.entry(i, msg)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, e)
+	}
+
+	batchErr := messaging.NewBatchError("sqs")
+	chunks := messaging.Chunk(entries, func(e batchEntry) int { return e.size })
+	for i, chunk := range chunks {
+		failed, err := p.sendChunk(ctx, chunk)
+		batchErr.Failed = append(batchErr.Failed, failed...)
+		if err != nil {
+			for _, unsent := range chunks[i+1:] {
+				batchErr.Failed = append(batchErr.Failed, messaging.Unsent(unsent, batchIndex, err)...)
+			}
+			batchErr.Err = err
+			return batchErr
+		}
+	}
+	if len(batchErr.Failed) > 0 {
+		return batchErr
+	}
+	return nil
+}
+
+func (p *Producer) sendChunk(ctx context.Context, pending []batchEntry) ([]BatchFailure, error) {
+	for i := range pending {
+		pending[i].entry.Id = aws.String(strconv.Itoa(i))
+	}
+	var failures []BatchFailure
+	for attempt := 0; ; attempt++ {
+		byID := make(map[string]batchEntry, len(pending))
+		input := &sqs.SendMessageBatchInput{QueueUrl: aws.String(p.cfg.QueueURL)}
+		for _, e := range pending {
+			byID[aws.ToString(e.entry.Id)] = e
+			input.Entries = append(input.Entries, e.entry)
+		}
+		out, err := p.client.SendMessageBatch(ctx, input)
+		if err != nil {
+			err = p.log.WrapError(err, "error enviando lote a sqs")
+			return append(failures, messaging.Unsent(pending, batchIndex, err)...), err
+		}
+
+		var retry []batchEntry
+		var retryFailures []BatchFailure
+		for _, f := range out.Failed {
+			e := byID[aws.ToString(f.Id)]
+			failure := BatchFailure{Index: e.index, Code: aws.ToString(f.Code), Message: aws.ToString(f.Message)}
+			if f.SenderFault {
+				failures = append(failures, failure)
+				continue
+			}
+			retry = append(retry, e)
+			retryFailures = append(retryFailures, failure)
+		}
+		if len(retry) == 0 {
+			return failures, nil
+		}
+		if attempt >= p.cfg.MaxRetries {
+			return append(failures, retryFailures...), nil
+		}
+		if !messaging.Backoff(ctx, sendRetryBackoff, attempt) {
+			return append(failures, retryFailures...), ctx.Err()
+		}
+		pending = retry
+	}
+}
+
+func batchIndex(e batchEntry) int {
+	return e.index
+}
+
+func (p *Producer) entry(index int, msg Message) (batchEntry, error) {
+	body, err := messaging.MarshalPayload(msg.Body)
+	if err != nil {
+		return batchEntry{}, p.log.WrapError(err, "error serializando mensaje")
+	}
+	e := types.SendMessageBatchRequestEntry{
+		MessageBody:  aws.String(body),
+		DelaySeconds: msg.DelaySeconds,
+	}
+	size := len(body)
+	if len(msg.Attributes) > 0 {
+		e.MessageAttributes = make(map[string]types.MessageAttributeValue, len(msg.Attributes))
+		for k, v := range msg.Attributes {
+			e.MessageAttributes[k] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(v)}
+			size += len(k) + len("String") + len(v)
+		}
+	}
+	if size > MaxBatchBytes {
+		return batchEntry{}, fmt.Errorf("%w: message %d has %d bytes", ErrMessageTooLarge, index, size)
+	}
+	if p.fifo {
+		groupID := msg.GroupID
+		if groupID == "" {
+			groupID = p.cfg.DefaultGroupID
+		}
+		if groupID == "" {
+			return batchEntry{}, fmt.Errorf("message %d: fifo queue requires a message group id", index)
+		}
+		dedupID := msg.DeduplicationID
+		if dedupID == "" {
+			hash := sha256.Sum256([]byte(body))
+			dedupID = hex.EncodeToString(hash[:])
+		}
+		e.MessageGroupId = aws.String(groupID)
+		e.MessageDeduplicationId = aws.String(dedupID)
+		e.DelaySeconds = 0
+	}
+	return batchEntry{index: index, entry: e, size: size}, nil
+}
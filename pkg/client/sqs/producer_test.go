@@ -0,0 +1,140 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/sqs/mock"
+	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+type transfer struct {
+	ID     string `json:"id"`
+	Amount int    `json:"amount"`
+}
+
+func newProducer(client *cl.Service, cfg ProducerConfig) *Producer {
+	logger := &log.Service{}
+	logger.On("WrapError", mock.Anything, mock.Anything).Return(func(err error, msg string) error {
+		return fmt.Errorf("%s: %w", msg, err)
+	}).Maybe()
+	return NewProducer(Dependencies{Client: &Sqs{Cliente: client}, Log: logger}, cfg)
+}
+
+func TestProducerSendMarshalsJSON(t *testing.T) {
+	client := &cl.Service{}
+	client.On("SendMessage", mock.Anything, mock.MatchedBy(func(in *sqs.SendMessageInput) bool {
+		return aws.ToString(in.MessageBody) == `{"id":"t-1","amount":10}` &&
+			aws.ToString(in.MessageAttributes["event_type"].StringValue) == "transfer_created" &&
+			in.MessageGroupId == nil
+	})).Return(&sqs.SendMessageOutput{MessageId: aws.String("m-1")}, nil)
+
+	producer := newProducer(client, ProducerConfig{QueueURL: "https://sqs/queue"})
+	id, err := producer.Send(context.Background(), Message{
+		Body:       transfer{ID: "t-1", Amount: 10},
+		Attributes: map[string]string{"event_type": "transfer_created"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "m-1", id)
+}
+
+func TestProducerFIFO(t *testing.T) {
+	client := &cl.Service{}
+	client.On("SendMessage", mock.Anything, mock.MatchedBy(func(in *sqs.SendMessageInput) bool {
+		return aws.ToString(in.MessageGroupId) == "account-1" && len(aws.ToString(in.MessageDeduplicationId)) == 64
+	})).Return(&sqs.SendMessageOutput{MessageId: aws.String("m-1")}, nil)
+
+	producer := newProducer(client, ProducerConfig{QueueURL: "https://sqs/queue.fifo"})
+
+	_, err := producer.Send(context.Background(), Message{Body: "payload"})
+	assert.ErrorContains(t, err, "message group id")
+
+	_, err = producer.Send(context.Background(), Message{Body: "payload", GroupID: "account-1"})
+	assert.NoError(t, err)
+}
+
+func TestProducerSendBatchChunks(t *testing.T) {
+	client := &cl.Service{}
+	var sizes []int
+	client.On("SendMessageBatch", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			sizes = append(sizes, len(args.Get(1).(*sqs.SendMessageBatchInput).Entries))
+		}).
+		Return(&sqs.SendMessageBatchOutput{}, nil)
+
+	producer := newProducer(client, ProducerConfig{QueueURL: "https://sqs/queue"})
+	messages := make([]Message, 23)
+	for i := range messages {
+		messages[i] = Message{Body: transfer{Amount: i}}
+	}
+	large := strings.Repeat("x", 100*1024)
+	messages = append(messages, Message{Body: large}, Message{Body: large}, Message{Body: large})
+
+	assert.NoError(t, producer.SendBatch(context.Background(), messages))
+	assert.Equal(t, []int{10, 10, 5, 1}, sizes)
+}
+
+func TestProducerRetriesPartialFailures(t *testing.T) {
+	client := &cl.Service{}
+	client.On("SendMessageBatch", mock.Anything, mock.MatchedBy(func(in *sqs.SendMessageBatchInput) bool {
+		return len(in.Entries) == 3
+	})).Return(&sqs.SendMessageBatchOutput{Failed: []types.BatchResultErrorEntry{
+		{Id: aws.String("1"), Code: aws.String("InternalError"), SenderFault: false},
+		{Id: aws.String("2"), Code: aws.String("InvalidMessageContents"), SenderFault: true},
+	}}, nil).Once()
+	client.On("SendMessageBatch", mock.Anything, mock.MatchedBy(func(in *sqs.SendMessageBatchInput) bool {
+		return len(in.Entries) == 1 && aws.ToString(in.Entries[0].Id) == "1"
+	})).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
+
+	producer := newProducer(client, ProducerConfig{QueueURL: "https://sqs/queue"})
+	err := producer.SendBatch(context.Background(), []Message{{Body: "a"}, {Body: "b"}, {Body: "c"}})
+
+	var batchErr *BatchError
+	assert.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, []BatchFailure{{Index: 2, Code: "InvalidMessageContents"}}, batchErr.Failed)
+	client.AssertExpectations(t)
+}
+
+func TestProducerReportsUnsentChunks(t *testing.T) {
+	cause := errors.New("connection reset")
+	client := &cl.Service{}
+	client.On("SendMessageBatch", mock.Anything, mock.MatchedBy(func(in *sqs.SendMessageBatchInput) bool {
+		return aws.ToString(in.Entries[0].MessageBody) == "0"
+	})).Return(&sqs.SendMessageBatchOutput{}, nil).Once()
+	client.On("SendMessageBatch", mock.Anything, mock.MatchedBy(func(in *sqs.SendMessageBatchInput) bool {
+		return aws.ToString(in.Entries[0].MessageBody) == "10"
+	})).Return(nil, cause).Once()
+
+	messages := make([]Message, 25)
+	for i := range messages {
+		messages[i] = Message{Body: fmt.Sprint(i)}
+	}
+	producer := newProducer(client, ProducerConfig{QueueURL: "https://sqs/queue"})
+	err := producer.SendBatch(context.Background(), messages)
+
+	var batchErr *BatchError
+	assert.ErrorAs(t, err, &batchErr)
+	assert.ErrorIs(t, err, cause)
+	indexes := make([]int, 0, len(batchErr.Failed))
+	for _, f := range batchErr.Failed {
+		indexes = append(indexes, f.Index)
+	}
+	assert.Equal(t, []int{10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24}, indexes)
+	client.AssertExpectations(t)
+}
+
+func TestProducerRejectsLargeMessages(t *testing.T) {
+	producer := newProducer(&cl.Service{}, ProducerConfig{QueueURL: "https://sqs/queue"})
+
+	err := producer.SendBatch(context.Background(), []Message{{Body: strings.Repeat("x", MaxBatchBytes+1)}})
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+}
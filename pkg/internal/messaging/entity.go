@@ -0,0 +1,22 @@
+package messaging
+
+const (
+	MaxBatchEntries = 10
+	MaxBatchBytes   = 256 * 1024
+)
+
+// BatchError reúne las entradas de un lote que no se pudieron enviar luego de los reintentos.
+// Si el envío se cortó por un error (p.ej. de red), Err es la causa y Failed incluye también
+// las entradas que no llegaron a enviarse; las que no figuran en Failed se enviaron.
+type BatchError struct {
+	Failed []BatchFailure
+	Err    error
+	source string
+}
+
+// BatchFailure indica la posición de la entrada en el slice original y el error devuelto por AWS.
+type BatchFailure struct {
+	Index   int
+	Code    string
+	Message string
+}
@@ -0,0 +1,88 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func NewBatchError(source string) *BatchError {
+	return &BatchError{source: source}
+}
+
+func (e *BatchError) Error() string {
+	msg := fmt.Sprintf("batch: %d entries failed", len(e.Failed))
+	if e.source != "" {
+		msg = e.source + " " + msg
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// Unsent arma las fallas de las entradas que no se enviaron porque el lote se cortó con err.
+func Unsent[T any](entries []T, index func(T) int, err error) []BatchFailure {
+	failures := make([]BatchFailure, 0, len(entries))
+	for _, e := range entries {
+		failures = append(failures, BatchFailure{Index: index(e), Message: err.Error()})
+	}
+	return failures
+}
+
+// Chunk agrupa las entradas en lotes de hasta MaxBatchEntries y MaxBatchBytes según size.
+func Chunk[T any](entries []T, size func(T) int) [][]T {
+	var chunks [][]T
+	var current []T
+	total := 0
+	for _, e := range entries {
+		n := size(e)
+		if len(current) == MaxBatchEntries || (len(current) > 0 && total+n > MaxBatchBytes) {
+			chunks = append(chunks, current)
+			current, total = nil, 0
+		}
+		current = append(current, e)
+		total += n
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// MarshalPayload serializa payload a JSON salvo que ya sea string o []byte.
+func MarshalPayload(payload interface{}) (string, error) {
+	switch p := payload.(type) {
+	case string:
+		return p, nil
+	case []byte:
+		return string(p), nil
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Sleep espera d o hasta que se cancele ctx; devuelve false si se canceló.
+func Sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Backoff espera base * 2^attempt o hasta que se cancele ctx; devuelve false si se canceló.
+func Backoff(ctx context.Context, base time.Duration, attempt int) bool {
+	return Sleep(ctx, base<<attempt)
+}
@@ -0,0 +1,67 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkBySizeAndCount(t *testing.T) {
+	sizes := make([]int, 0, 26)
+	for i := 0; i < 23; i++ {
+		sizes = append(sizes, 1)
+	}
+	sizes = append(sizes, 100*1024, 100*1024, 100*1024)
+
+	chunks := Chunk(sizes, func(n int) int { return n })
+
+	lengths := make([]int, 0, len(chunks))
+	for _, c := range chunks {
+		lengths = append(lengths, len(c))
+	}
+	assert.Equal(t, []int{10, 10, 5, 1}, lengths)
+	assert.Nil(t, Chunk([]int{}, func(n int) int { return n }))
+}
+
+func TestMarshalPayload(t *testing.T) {
+	s, err := MarshalPayload("raw")
+	assert.NoError(t, err)
+	assert.Equal(t, "raw", s)
+
+	s, err = MarshalPayload([]byte("bytes"))
+	assert.NoError(t, err)
+	assert.Equal(t, "bytes", s)
+
+	s, err = MarshalPayload(map[string]int{"amount": 10})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"amount":10}`, s)
+
+	_, err = MarshalPayload(make(chan int))
+	assert.Error(t, err)
+}
+
+func TestBatchError(t *testing.T) {
+	var err error = &BatchError{Failed: []BatchFailure{{Index: 1}}, source: "sqs"}
+
+	var batchErr *BatchError
+	assert.True(t, errors.As(err, &batchErr))
+	assert.EqualError(t, err, "sqs batch: 1 entries failed")
+	assert.EqualError(t, &BatchError{}, "batch: 0 entries failed")
+
+	cause := errors.New("timeout")
+	err = &BatchError{Failed: Unsent([]int{3, 4}, func(i int) int { return i }, cause), Err: cause, source: "sns"}
+	assert.ErrorIs(t, err, cause)
+	assert.EqualError(t, err, "sns batch: 2 entries failed: timeout")
+	assert.Equal(t, []BatchFailure{{Index: 3, Message: "timeout"}, {Index: 4, Message: "timeout"}}, err.(*BatchError).Failed)
+}
+
+func TestSleepCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.False(t, Sleep(ctx, time.Minute))
+	assert.True(t, Sleep(context.Background(), time.Millisecond))
+}
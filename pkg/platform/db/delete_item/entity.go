@@ -0,0 +1,18 @@
+package delete_item
+
+import (
+	"context"
+
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+)
+
+type Service interface {
+	Accept(ctx context.Context, key map[string]interface{}, table string, conditions ...expression.Condition) error
+}
+
+type Dependencies struct {
+	Client *dynamo.Dynamo
+	Log    log.Service
+}
@@ -0,0 +1,52 @@
+package delete_item
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+)
+
+type service struct {
+	client *dynamo.Dynamo
+	log    log.Service
+}
+
+var _ Service = (*service)(nil)
+
+func NewService(d Dependencies) *service {
+	return &service{
+		client: d.Client,
+		log:    d.Log,
+	}
+}
+
+// Accept elimina el item; con conditions el borrado solo se aplica si se cumplen todas,
+// y si no devuelve un error que cumple errors.Is(err, expression.ErrConditionFailed).
+func (s *service) Accept(ctx context.Context, key map[string]interface{}, table string, conditions ...expression.Condition) error {
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return s.log.WrapError(err, "error serializando clave")
+	}
+	input := &dynamodb.DeleteItemInput{
+		TableName: &table,
+		Key:       k,
+	}
+	if len(conditions) > 0 {
+		e, err := expression.Build(nil, expression.And(conditions...))
+		if err != nil {
+			return s.log.WrapError(err, "error armando la condición")
+		}
+		input.ConditionExpression = e.Condition
+		input.ExpressionAttributeNames = e.Names
+		input.ExpressionAttributeValues = e.Values
+	}
+	_, err = s.client.Cliente.DeleteItem(ctx, input)
+	if err != nil {
+		return s.log.WrapError(expression.ConditionFailed(err), "error al eliminar el item")
+	}
+	return nil
+}
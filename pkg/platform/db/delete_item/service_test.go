@@ -0,0 +1,47 @@
+package delete_item
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/dynamo/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+func TestDeleteSuccess(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("DeleteItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.DeleteItemInput) bool {
+		return aws.ToString(in.TableName) == "table" && in.ConditionExpression == nil
+	})).Return(&dynamodb.DeleteItemOutput{}, nil)
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	err := cliente.Accept(context.TODO(), map[string]interface{}{"PK": "123"}, "table")
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteConditionFailed(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("DeleteItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.DeleteItemInput) bool {
+		return aws.ToString(in.ConditionExpression) == "#n0 = :v0"
+	})).Return(nil, &types.ConditionalCheckFailedException{Message: aws.String("conditional request failed")})
+	l.On("WrapError", mock.Anything, "error al eliminar el item").
+		Return(func(err error, msg string) error { return errors.Wrap(err, msg) })
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	err := cliente.Accept(context.TODO(), map[string]interface{}{"PK": "123"}, "table", expression.Equal("status", "CLOSED"))
+
+	assert.ErrorIs(t, err, expression.ErrConditionFailed)
+}
@@ -0,0 +1,50 @@
+package expression
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ErrConditionFailed indica que la condición de una escritura no se cumplió
+// (p.ej. el item ya existía o cambió desde que se leyó).
+var ErrConditionFailed = errors.New("condition check failed")
+
+// ErrVersionConflict indica que el item fue modificado por otro proceso desde que se leyó;
+// hay que releerlo y reintentar.
+var ErrVersionConflict = errors.New("version conflict")
+
+var listIndex = regexp.MustCompile(`^(\[[0-9]+\])+$`)
+
+// Condition arma una condition expression; se combinan con And y Or.
+type Condition func(b *builder) string
+
+// Update acumula las acciones SET, REMOVE y ADD de una update expression.
+type Update struct {
+	set    []action
+	remove []string
+	add    []action
+}
+
+// Expression contiene las expresiones listas para asignar a los inputs de DynamoDB.
+// Los campos vacíos quedan en nil, como los espera la API.
+type Expression struct {
+	Update    *string
+	Condition *string
+	Names     map[string]string
+	Values    map[string]types.AttributeValue
+}
+
+type action struct {
+	name  string
+	value interface{}
+}
+
+type builder struct {
+	names     map[string]string
+	aliases   map[string]string
+	values    map[string]types.AttributeValue
+	nextValue int
+	err       error
+}
@@ -0,0 +1,194 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Build arma la update expression y la condición; cualquiera de las dos puede ser nil.
+// Los nombres de atributo se reemplazan siempre por placeholders, por lo que se admiten
+// palabras reservadas y rutas anidadas ("address.city", "items[0].qty").
+func Build(update *Update, condition Condition) (Expression, error) {
+	b := &builder{
+		names:   map[string]string{},
+		aliases: map[string]string{},
+		values:  map[string]types.AttributeValue{},
+	}
+	var e Expression
+	if update != nil {
+		expr := update.render(b)
+		if expr == "" {
+			return Expression{}, errors.New("expression: empty update")
+		}
+		e.Update = aws.String(expr)
+	}
+	if condition != nil {
+		e.Condition = aws.String(condition(b))
+	}
+	if b.err != nil {
+		return Expression{}, b.err
+	}
+	if len(b.names) > 0 {
+		e.Names = b.names
+	}
+	if len(b.values) > 0 {
+		e.Values = b.values
+	}
+	return e, nil
+}
+
+// ConditionFailed traduce ConditionalCheckFailedException a ErrConditionFailed, conservando el error original.
+func ConditionFailed(err error) error {
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return fmt.Errorf("%w: %w", ErrConditionFailed, err)
+	}
+	return err
+}
+
+func NewUpdate() *Update {
+	return &Update{}
+}
+
+// Set asigna value al atributo.
+func (u *Update) Set(name string, value interface{}) *Update {
+	u.set = append(u.set, action{name: name, value: value})
+	return u
+}
+
+// Remove elimina el atributo del item.
+func (u *Update) Remove(name string) *Update {
+	u.remove = append(u.remove, name)
+	return u
+}
+
+// Add suma value a un atributo numérico (o agrega elementos a un set); si no existe lo crea.
+func (u *Update) Add(name string, value interface{}) *Update {
+	u.add = append(u.add, action{name: name, value: value})
+	return u
+}
+
+func (u *Update) render(b *builder) string {
+	var clauses []string
+	if len(u.set) > 0 {
+		parts := make([]string, 0, len(u.set))
+		for _, a := range u.set {
+			parts = append(parts, b.name(a.name)+" = "+b.value(a.value))
+		}
+		clauses = append(clauses, "SET "+strings.Join(parts, ", "))
+	}
+	if len(u.remove) > 0 {
+		parts := make([]string, 0, len(u.remove))
+		for _, name := range u.remove {
+			parts = append(parts, b.name(name))
+		}
+		clauses = append(clauses, "REMOVE "+strings.Join(parts, ", "))
+	}
+	if len(u.add) > 0 {
+		parts := make([]string, 0, len(u.add))
+		for _, a := range u.add {
+			parts = append(parts, b.name(a.name)+" "+b.value(a.value))
+		}
+		clauses = append(clauses, "ADD "+strings.Join(parts, ", "))
+	}
+	return strings.Join(clauses, " ")
+}
+
+// AttributeNotExists se cumple si el atributo no existe; sobre la clave sirve como put-if-absent.
+func AttributeNotExists(name string) Condition {
+	return func(b *builder) string {
+		return "attribute_not_exists(" + b.name(name) + ")"
+	}
+}
+
+func AttributeExists(name string) Condition {
+	return func(b *builder) string {
+		return "attribute_exists(" + b.name(name) + ")"
+	}
+}
+
+// Equal se cumple si el atributo vale value; sirve para compare-and-set.
+func Equal(name string, value interface{}) Condition {
+	return compare(name, "=", value)
+}
+
+func NotEqual(name string, value interface{}) Condition {
+	return compare(name, "<>", value)
+}
+
+func LessThan(name string, value interface{}) Condition {
+	return compare(name, "<", value)
+}
+
+func GreaterThanEqual(name string, value interface{}) Condition {
+	return compare(name, ">=", value)
+}
+
+func And(conditions ...Condition) Condition {
+	return join(" AND ", conditions)
+}
+
+func Or(conditions ...Condition) Condition {
+	return join(" OR ", conditions)
+}
+
+func compare(name, operator string, value interface{}) Condition {
+	return func(b *builder) string {
+		return b.name(name) + " " + operator + " " + b.value(value)
+	}
+}
+
+func join(operator string, conditions []Condition) Condition {
+	return func(b *builder) string {
+		if len(conditions) == 1 {
+			return conditions[0](b)
+		}
+		parts := make([]string, 0, len(conditions))
+		for _, c := range conditions {
+			parts = append(parts, "("+c(b)+")")
+		}
+		return strings.Join(parts, operator)
+	}
+}
+
+// name reemplaza cada segmento de path por un placeholder; los índices de lista
+// (p.ej. items[0].qty) quedan fuera del placeholder: #n0[0].#n1.
+func (b *builder) name(path string) string {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		index := ""
+		if at := strings.IndexByte(part, '['); at > 0 {
+			part, index = part[:at], part[at:]
+			if !listIndex.MatchString(index) && b.err == nil {
+				b.err = fmt.Errorf("expression: invalid list index in %q", path)
+			}
+		}
+		alias, ok := b.aliases[part]
+		if !ok {
+			alias = fmt.Sprintf("#n%d", len(b.aliases))
+			b.aliases[part] = alias
+			b.names[alias] = part
+		}
+		parts[i] = alias + index
+	}
+	return strings.Join(parts, ".")
+}
+
+func (b *builder) value(v interface{}) string {
+	placeholder := fmt.Sprintf(":v%d", b.nextValue)
+	b.nextValue++
+	av, err := attributevalue.Marshal(v)
+	if err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("expression: value for %s: %w", placeholder, err)
+		}
+		return placeholder
+	}
+	b.values[placeholder] = av
+	return placeholder
+}
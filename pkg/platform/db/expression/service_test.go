@@ -0,0 +1,94 @@
+package expression
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildUpdateWithCondition(t *testing.T) {
+	update := NewUpdate().Set("status", "ACTIVE").Set("address.city", "CABA").Remove("token").Add("balance", 100)
+
+	e, err := Build(update, And(AttributeExists("PK"), Equal("status", "PENDING")))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SET #n0 = :v0, #n1.#n2 = :v1 REMOVE #n3 ADD #n4 :v2", aws.ToString(e.Update))
+	assert.Equal(t, "(attribute_exists(#n5)) AND (#n0 = :v3)", aws.ToString(e.Condition))
+	assert.Equal(t, map[string]string{
+		"#n0": "status", "#n1": "address", "#n2": "city", "#n3": "token", "#n4": "balance", "#n5": "PK",
+	}, e.Names)
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "100"}, e.Values[":v2"])
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "PENDING"}, e.Values[":v3"])
+}
+
+func TestBuildListIndexPaths(t *testing.T) {
+	update := NewUpdate().Set("items[0].qty", 2).Remove("items[1]").Set("matrix[0][1]", 3)
+
+	e, err := Build(update, AttributeExists("items[0]"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SET #n0[0].#n1 = :v0, #n2[0][1] = :v1 REMOVE #n0[1]", aws.ToString(e.Update))
+	assert.Equal(t, "attribute_exists(#n0[0])", aws.ToString(e.Condition))
+	assert.Equal(t, map[string]string{"#n0": "items", "#n1": "qty", "#n2": "matrix"}, e.Names)
+
+	_, err = Build(NewUpdate().Set("items[x]", 1), nil)
+	assert.Error(t, err)
+}
+
+func TestBuildConditionOnly(t *testing.T) {
+	e, err := Build(nil, AttributeNotExists("PK"))
+
+	assert.NoError(t, err)
+	assert.Nil(t, e.Update)
+	assert.Nil(t, e.Values)
+	assert.Equal(t, "attribute_not_exists(#n0)", aws.ToString(e.Condition))
+}
+
+func TestBuildEmptyUpdate(t *testing.T) {
+	_, err := Build(NewUpdate(), nil)
+	assert.Error(t, err)
+}
+
+func TestConditionFailed(t *testing.T) {
+	err := ConditionFailed(&types.ConditionalCheckFailedException{Message: aws.String("conditional request failed")})
+	var original *types.ConditionalCheckFailedException
+
+	assert.ErrorIs(t, err, ErrConditionFailed)
+	assert.ErrorAs(t, err, &original)
+	assert.NotErrorIs(t, ConditionFailed(errors.New("boom")), ErrConditionFailed)
+}
+
+func TestVersion(t *testing.T) {
+	item := map[string]types.AttributeValue{"PK": &types.AttributeValueMemberS{Value: "1"}}
+
+	v, err := Version(item, "version")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), v)
+
+	SetVersion(item, "version", 4)
+	v, err = Version(item, "version")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), v)
+
+	_, err = Version(map[string]types.AttributeValue{"version": &types.AttributeValueMemberS{Value: "4"}}, "version")
+	assert.Error(t, err)
+}
+
+func TestVersionConditionAndConflict(t *testing.T) {
+	e, err := Build(nil, VersionCondition("version", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "attribute_not_exists(#n0)", aws.ToString(e.Condition))
+
+	e, err = Build(nil, VersionCondition("version", 3))
+	assert.NoError(t, err)
+	assert.Equal(t, "#n0 = :v0", aws.ToString(e.Condition))
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "3"}, e.Values[":v0"])
+
+	conflict := VersionConflict(&types.ConditionalCheckFailedException{Message: aws.String("conditional request failed")})
+	assert.ErrorIs(t, conflict, ErrVersionConflict)
+	assert.ErrorIs(t, conflict, ErrConditionFailed)
+	assert.NotErrorIs(t, VersionConflict(errors.New("boom")), ErrVersionConflict)
+}
@@ -0,0 +1,47 @@
+package expression
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// VersionCondition exige que el atributo de versión siga valiendo read; con read 0 exige que no exista.
+func VersionCondition(name string, read int64) Condition {
+	if read == 0 {
+		return AttributeNotExists(name)
+	}
+	return Equal(name, read)
+}
+
+// VersionConflict traduce el fallo de la condición de versión a ErrVersionConflict
+// (que además cumple errors.Is(err, ErrConditionFailed)).
+func VersionConflict(err error) error {
+	translated := ConditionFailed(err)
+	if translated == err {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrVersionConflict, translated)
+}
+
+// Version lee el número de versión de un item serializado; 0 si no tiene.
+func Version(item map[string]types.AttributeValue, name string) (int64, error) {
+	av, ok := item[name]
+	if !ok {
+		return 0, nil
+	}
+	if _, null := av.(*types.AttributeValueMemberNULL); null {
+		return 0, nil
+	}
+	n, ok := av.(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("version attribute %s must be a number", name)
+	}
+	return strconv.ParseInt(n.Value, 10, 64)
+}
+
+// SetVersion guarda version en el item serializado.
+func SetVersion(item map[string]types.AttributeValue, name string, version int64) {
+	item[name] = &types.AttributeValueMemberN{Value: strconv.FormatInt(version, 10)}
+}
@@ -2,6 +2,8 @@ package query
 
 import (
 	"context"
+	"errors"
+	"iter"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
@@ -9,11 +11,29 @@ import (
 	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
 )
 
+// ErrInvalidPageToken indica que el token de página recibido no es válido.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
 type Service interface {
 	Apply(ctx context.Context, item *dynamodb.QueryInput) ([]map[string]types.AttributeValue, error)
+	Iterate(ctx context.Context, input *dynamodb.QueryInput, maxItems int) iter.Seq2[map[string]types.AttributeValue, error]
+	Page(ctx context.Context, input *dynamodb.QueryInput, limit int32, token string) (Page, error)
+}
+
+// Page es una página de resultados; NextToken está vacío cuando no hay más items.
+type Page struct {
+	Items     []map[string]types.AttributeValue
+	NextToken string
 }
 
 type Dependencies struct {
 	Client *dynamo.Dynamo
 	Log    log.Service
 }
+
+// tokenValue es la representación serializada de un atributo de clave (S, N o B).
+type tokenValue struct {
+	S *string `json:"s,omitempty"`
+	N *string `json:"n,omitempty"`
+	B []byte  `json:"b,omitempty"`
+}
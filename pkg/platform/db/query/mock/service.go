@@ -4,10 +4,14 @@ package mocks
 
 import (
 	context "context"
+	iter "iter"
 
 	dynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
 	mock "github.com/stretchr/testify/mock"
 
+	query "github.com/uala-challenge/simple-toolkit/pkg/platform/db/query"
+
 	types "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
@@ -46,6 +50,54 @@ func (_m *Service) Apply(ctx context.Context, item *dynamodb.QueryInput) ([]map[
 	return r0, r1
 }
 
+// Iterate provides a mock function with given fields: ctx, input, maxItems
+func (_m *Service) Iterate(ctx context.Context, input *dynamodb.QueryInput, maxItems int) iter.Seq2[map[string]types.AttributeValue, error] {
+	ret := _m.Called(ctx, input, maxItems)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Iterate")
+	}
+
+	var r0 iter.Seq2[map[string]types.AttributeValue, error]
+	if rf, ok := ret.Get(0).(func(context.Context, *dynamodb.QueryInput, int) iter.Seq2[map[string]types.AttributeValue, error]); ok {
+		r0 = rf(ctx, input, maxItems)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[map[string]types.AttributeValue, error])
+		}
+	}
+
+	return r0
+}
+
+// Page provides a mock function with given fields: ctx, input, limit, token
+func (_m *Service) Page(ctx context.Context, input *dynamodb.QueryInput, limit int32, token string) (query.Page, error) {
+	ret := _m.Called(ctx, input, limit, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Page")
+	}
+
+	var r0 query.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dynamodb.QueryInput, int32, string) (query.Page, error)); ok {
+		return rf(ctx, input, limit, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dynamodb.QueryInput, int32, string) query.Page); ok {
+		r0 = rf(ctx, input, limit, token)
+	} else {
+		r0 = ret.Get(0).(query.Page)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dynamodb.QueryInput, int32, string) error); ok {
+		r1 = rf(ctx, input, limit, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
@@ -2,7 +2,9 @@ package query
 
 import (
 	"context"
+	"iter"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
@@ -23,21 +25,95 @@ func NewService(d Dependencies) *service {
 	}
 }
 
+// Apply devuelve todos los items de la consulta. Para particiones grandes conviene Iterate o Page.
 func (s service) Apply(ctx context.Context, query *dynamodb.QueryInput) ([]map[string]types.AttributeValue, error) {
 	var results []map[string]types.AttributeValue
-	for {
-		output, err := s.client.Cliente.Query(ctx, query)
+	for item, err := range s.Iterate(ctx, query, 0) {
 		if err != nil {
-			return nil, s.log.WrapError(err, "error al ejecutar Query")
+			return nil, err
 		}
-		if len(output.Items) > 0 {
-			results = append(results, output.Items...)
+		results = append(results, item)
+	}
+	return results, nil
+}
+
+// Iterate recorre los items página por página sin modificar input, hasta maxItems si es mayor a 0;
+// en ese caso el Limit de cada página no supera los items que faltan.
+// Antes de cada página verifica ctx; el error, si lo hay, es el último valor producido.
+func (s service) Iterate(ctx context.Context, input *dynamodb.QueryInput, maxItems int) iter.Seq2[map[string]types.AttributeValue, error] {
+	return func(yield func(map[string]types.AttributeValue, error) bool) {
+		in := *input
+		count := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, s.log.WrapError(err, "error al ejecutar Query"))
+				return
+			}
+			if maxItems > 0 {
+				limit := int32(maxItems - count)
+				if l := aws.ToInt32(input.Limit); l > 0 {
+					limit = min(limit, l)
+				}
+				in.Limit = aws.Int32(limit)
+			}
+			output, err := s.client.Cliente.Query(ctx, &in)
+			if err != nil {
+				yield(nil, s.log.WrapError(err, "error al ejecutar Query"))
+				return
+			}
+			for _, item := range output.Items {
+				if !yield(item, nil) {
+					return
+				}
+				count++
+				if maxItems > 0 && count >= maxItems {
+					return
+				}
+			}
+			if len(output.LastEvaluatedKey) == 0 {
+				return
+			}
+			in.ExclusiveStartKey = output.LastEvaluatedKey
 		}
-		if output.LastEvaluatedKey == nil {
-			break
+	}
+}
+
+// Page devuelve hasta limit items a partir de token (vacío para la primera página) y el
+// token de la siguiente. Los tokens son opacos y pueden devolverse tal cual a los clientes HTTP.
+func (s service) Page(ctx context.Context, input *dynamodb.QueryInput, limit int32, token string) (Page, error) {
+	if limit <= 0 {
+		return Page{}, s.log.WrapError(nil, "el límite de la página debe ser mayor a 0")
+	}
+	in := *input
+	if token != "" {
+		key, err := DecodePageToken(token)
+		if err != nil {
+			return Page{}, s.log.WrapError(err, "error leyendo el token de página")
 		}
-		query.ExclusiveStartKey = output.LastEvaluatedKey
+		in.ExclusiveStartKey = key
 	}
 
-	return results, nil
+	page := Page{Items: make([]map[string]types.AttributeValue, 0, limit)}
+	for {
+		if err := ctx.Err(); err != nil {
+			return Page{}, s.log.WrapError(err, "error al ejecutar Query")
+		}
+		in.Limit = aws.Int32(limit - int32(len(page.Items)))
+		output, err := s.client.Cliente.Query(ctx, &in)
+		if err != nil {
+			return Page{}, s.log.WrapError(err, "error al ejecutar Query")
+		}
+		page.Items = append(page.Items, output.Items...)
+		if len(output.LastEvaluatedKey) == 0 {
+			return page, nil
+		}
+		if int32(len(page.Items)) >= limit {
+			page.NextToken, err = EncodePageToken(output.LastEvaluatedKey)
+			if err != nil {
+				return Page{}, s.log.WrapError(err, "error generando el token de página")
+			}
+			return page, nil
+		}
+		in.ExclusiveStartKey = output.LastEvaluatedKey
+	}
 }
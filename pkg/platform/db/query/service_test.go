@@ -105,3 +105,144 @@ func TestQueryDynamoError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, rps)
 }
+
+func pageOutput(lastKey string, values ...string) *dynamodb.QueryOutput {
+	out := &dynamodb.QueryOutput{}
+	for _, v := range values {
+		out.Items = append(out.Items, map[string]types.AttributeValue{"PK": &types.AttributeValueMemberS{Value: v}})
+	}
+	if lastKey != "" {
+		out.LastEvaluatedKey = map[string]types.AttributeValue{"PK": &types.AttributeValueMemberS{Value: lastKey}}
+	}
+	return out
+}
+
+func TestIterateStopsAtMaxItemsWithoutMutatingInput(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return in.ExclusiveStartKey == nil
+	})).Return(pageOutput("b", "a", "b"), nil).Once()
+	cli.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return in.ExclusiveStartKey != nil
+	})).Return(pageOutput("d", "c", "d"), nil).Once()
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	query := &dynamodb.QueryInput{TableName: aws.String("UalaChallenge")}
+
+	var got []string
+	for item, err := range cliente.Iterate(context.TODO(), query, 3) {
+		assert.NoError(t, err)
+		got = append(got, item["PK"].(*types.AttributeValueMemberS).Value)
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, got)
+	assert.Nil(t, query.ExclusiveStartKey)
+}
+
+func TestIterateCapsPageLimitToRemainingItems(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return in.ExclusiveStartKey == nil && aws.ToInt32(in.Limit) == 2
+	})).Return(pageOutput("b", "a", "b"), nil).Once()
+	cli.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return in.ExclusiveStartKey != nil && aws.ToInt32(in.Limit) == 1
+	})).Return(pageOutput("c", "c"), nil).Once()
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	query := &dynamodb.QueryInput{TableName: aws.String("UalaChallenge"), Limit: aws.Int32(2)}
+
+	var got []string
+	for item, err := range cliente.Iterate(context.TODO(), query, 3) {
+		assert.NoError(t, err)
+		got = append(got, item["PK"].(*types.AttributeValueMemberS).Value)
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, got)
+	assert.Equal(t, int32(2), aws.ToInt32(query.Limit))
+}
+
+func TestIterateChecksContextBetweenPages(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cli.On("Query", mock.Anything, mock.Anything).Return(pageOutput("a", "a"), nil).Once()
+	l.On("WrapError", context.Canceled, "error al ejecutar Query").Return(context.Canceled)
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+
+	var lastErr error
+	for _, err := range cliente.Iterate(ctx, &dynamodb.QueryInput{TableName: aws.String("UalaChallenge")}, 0) {
+		cancel()
+		lastErr = err
+	}
+
+	assert.ErrorIs(t, lastErr, context.Canceled)
+}
+
+func TestPageWithToken(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return in.ExclusiveStartKey == nil && aws.ToInt32(in.Limit) == 3
+	})).Return(pageOutput("b", "a", "b"), nil).Once()
+	cli.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return in.ExclusiveStartKey != nil && aws.ToInt32(in.Limit) == 1
+	})).Return(pageOutput("c", "c"), nil).Once()
+	cli.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return in.ExclusiveStartKey["PK"].(*types.AttributeValueMemberS).Value == "c"
+	})).Return(pageOutput("", "d"), nil).Once()
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	query := &dynamodb.QueryInput{TableName: aws.String("UalaChallenge")}
+
+	first, err := cliente.Page(context.TODO(), query, 3, "")
+	assert.NoError(t, err)
+	assert.Len(t, first.Items, 3)
+	assert.NotEmpty(t, first.NextToken)
+
+	second, err := cliente.Page(context.TODO(), query, 3, first.NextToken)
+	assert.NoError(t, err)
+	assert.Len(t, second.Items, 1)
+	assert.Empty(t, second.NextToken)
+	assert.Nil(t, query.ExclusiveStartKey)
+	assert.Nil(t, query.Limit)
+}
+
+func TestPageInvalidToken(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	l.On("WrapError", mock.Anything, "error leyendo el token de página").
+		Return(func(err error, msg string) error { return errors.Wrap(err, msg) })
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	_, err := cliente.Page(context.TODO(), &dynamodb.QueryInput{TableName: aws.String("UalaChallenge")}, 10, "no-es-un-token")
+
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	key := map[string]types.AttributeValue{
+		"PK":      &types.AttributeValueMemberS{Value: "user:1"},
+		"created": &types.AttributeValueMemberN{Value: "1700000000"},
+		"hash":    &types.AttributeValueMemberB{Value: []byte{1, 2, 3}},
+	}
+
+	token, err := EncodePageToken(key)
+	assert.NoError(t, err)
+	decoded, err := DecodePageToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, key, decoded)
+
+	_, err = EncodePageToken(map[string]types.AttributeValue{"PK": &types.AttributeValueMemberBOOL{Value: true}})
+	assert.Error(t, err)
+	_, err = DecodePageToken("e30")
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// EncodePageToken serializa un LastEvaluatedKey como token opaco (JSON en base64 URL-safe).
+func EncodePageToken(key map[string]types.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+	values := make(map[string]tokenValue, len(key))
+	for name, av := range key {
+		switch v := av.(type) {
+		case *types.AttributeValueMemberS:
+			values[name] = tokenValue{S: &v.Value}
+		case *types.AttributeValueMemberN:
+			values[name] = tokenValue{N: &v.Value}
+		case *types.AttributeValueMemberB:
+			values[name] = tokenValue{B: v.Value}
+		default:
+			return "", fmt.Errorf("page token: unsupported key attribute %s of type %T", name, av)
+		}
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodePageToken recupera el ExclusiveStartKey de un token generado por EncodePageToken.
+func DecodePageToken(token string) (map[string]types.AttributeValue, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPageToken, err)
+	}
+	var values map[string]tokenValue
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPageToken, err)
+	}
+	if len(values) == 0 {
+		return nil, ErrInvalidPageToken
+	}
+	key := make(map[string]types.AttributeValue, len(values))
+	for name, v := range values {
+		switch {
+		case v.S != nil:
+			key[name] = &types.AttributeValueMemberS{Value: *v.S}
+		case v.N != nil:
+			key[name] = &types.AttributeValueMemberN{Value: *v.N}
+		case v.B != nil:
+			key[name] = &types.AttributeValueMemberB{Value: v.B}
+		default:
+			return nil, fmt.Errorf("%w: empty value for %s", ErrInvalidPageToken, name)
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+)
+
+const (
+	maxBatchGetKeys  = 100
+	batchGetAttempts = 3
+	batchGetBackoff  = 100 * time.Millisecond
+)
+
+var ErrNotFound = errors.New("item not found")
+
+// UnprocessedKeysError lista las claves que BatchGet no pudo leer luego de los reintentos;
+// los items obtenidos se devuelven igual junto con el error.
+type UnprocessedKeysError struct {
+	Table string
+	Keys  []map[string]types.AttributeValue
+}
+
+// Config se declara en repositories.<name>, p.ej. {table: accounts, partition_key: PK, sort_key: SK}.
+// Con version_attribute, Put aplica optimistic locking sobre ese atributo numérico.
+type Config struct {
+	Table            string `json:"table" yaml:"table"`
+	PartitionKey     string `json:"partition_key" yaml:"partition_key"`
+	SortKey          string `json:"sort_key" yaml:"sort_key"`
+	VersionAttribute string `json:"version_attribute" yaml:"version_attribute"`
+}
+
+// Service expone operaciones tipadas sobre una tabla; T se serializa con los tags dynamodbav.
+type Service[T any] interface {
+	Get(ctx context.Context, key Key) (T, error)
+	Put(ctx context.Context, item *T) error
+	Delete(ctx context.Context, key Key) error
+	Query(ctx context.Context, partitionKey interface{}, opts ...QueryOption) ([]T, error)
+	BatchGet(ctx context.Context, keys []Key) ([]T, error)
+}
+
+// Key identifica un item; SortKey se ignora si la tabla no tiene sort key.
+type Key struct {
+	PartitionKey interface{}
+	SortKey      interface{}
+}
+
+type QueryOption func(*queryOptions)
+
+type queryOptions struct {
+	index        string
+	partitionKey string
+	sortKey      string
+	operator     string
+	values       []interface{}
+	limit        int32
+	descending   bool
+}
+
+type Dependencies struct {
+	Client *dynamo.Dynamo
+	Log    log.Service
+}
+
+type Repository[T any] struct {
+	client dynamo.Service
+	log    log.Service
+	cfg    Config
+}
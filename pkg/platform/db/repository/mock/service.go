@@ -0,0 +1,162 @@
+// Code generated by mockery v2.52.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	repository "github.com/uala-challenge/simple-toolkit/pkg/platform/db/repository"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service[T interface{}] struct {
+	mock.Mock
+}
+
+// BatchGet provides a mock function with given fields: ctx, keys
+func (_m *Service[T]) BatchGet(ctx context.Context, keys []repository.Key) ([]T, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchGet")
+	}
+
+	var r0 []T
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []repository.Key) ([]T, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []repository.Key) []T); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []repository.Key) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, key
+func (_m *Service[T]) Delete(ctx context.Context, key repository.Key) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Key) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *Service[T]) Get(ctx context.Context, key repository.Key) (T, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 T
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Key) (T, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.Key) T); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.Key) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Put provides a mock function with given fields: ctx, item
+func (_m *Service[T]) Put(ctx context.Context, item *T) error {
+	ret := _m.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *T) error); ok {
+		r0 = rf(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Query provides a mock function with given fields: ctx, partitionKey, opts
+func (_m *Service[T]) Query(ctx context.Context, partitionKey interface{}, opts ...repository.QueryOption) ([]T, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, partitionKey)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Query")
+	}
+
+	var r0 []T
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, ...repository.QueryOption) ([]T, error)); ok {
+		return rf(ctx, partitionKey, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, ...repository.QueryOption) []T); ok {
+		r0 = rf(ctx, partitionKey, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, ...repository.QueryOption) error); ok {
+		r1 = rf(ctx, partitionKey, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService[T interface{}](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service[T] {
+	mock := &Service[T]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
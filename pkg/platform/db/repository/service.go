@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/internal/messaging"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+)
+
+var _ Service[struct{}] = (*Repository[struct{}])(nil)
+
+func NewRepository[T any](d Dependencies, cfg Config) *Repository[T] {
+	return &Repository[T]{
+		client: d.Client.Cliente,
+		log:    d.Log,
+		cfg:    cfg,
+	}
+}
+
+// WithIndex consulta un índice secundario con sus propios atributos de clave.
+func WithIndex(name, partitionKey, sortKey string) QueryOption {
+	return func(o *queryOptions) {
+		o.index = name
+		o.partitionKey = partitionKey
+		o.sortKey = sortKey
+	}
+}
+
+// BeginsWith filtra por prefijo de la sort key.
+func BeginsWith(prefix string) QueryOption {
+	return func(o *queryOptions) {
+		o.operator = "begins_with"
+		o.values = []interface{}{prefix}
+	}
+}
+
+// Between filtra la sort key en el rango [from, to].
+func Between(from, to interface{}) QueryOption {
+	return func(o *queryOptions) {
+		o.operator = "between"
+		o.values = []interface{}{from, to}
+	}
+}
+
+// WithLimit corta la consulta al alcanzar n items.
+func WithLimit(n int32) QueryOption {
+	return func(o *queryOptions) {
+		o.limit = n
+	}
+}
+
+// Descending devuelve los items en orden descendente de sort key.
+func Descending() QueryOption {
+	return func(o *queryOptions) {
+		o.descending = true
+	}
+}
+
+func (r *Repository[T]) Get(ctx context.Context, key Key) (T, error) {
+	var out T
+	k, err := r.key(key)
+	if err != nil {
+		return out, r.log.WrapError(err, "error serializando clave de búsqueda")
+	}
+	result, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.cfg.Table),
+		Key:       k,
+	})
+	if err != nil {
+		return out, r.log.WrapError(err, "error al obtener el item")
+	}
+	if result.Item == nil {
+		return out, r.log.WrapError(ErrNotFound, "item no encontrado")
+	}
+	if err := attributevalue.UnmarshalMap(result.Item, &out); err != nil {
+		return out, r.log.WrapError(err, "error deserializando item")
+	}
+	return out, nil
+}
+
+// Put guarda el item. Con VersionAttribute, item debe traer la versión leída (0 si es nuevo):
+// se guarda con la versión incrementada, que se escribe de vuelta en item, y si otro proceso
+// lo modificó devuelve un error que cumple errors.Is(err, expression.ErrVersionConflict).
+func (r *Repository[T]) Put(ctx context.Context, item *T) error {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return r.log.WrapError(err, "Error serializando item")
+	}
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(r.cfg.Table),
+		Item:      av,
+	}
+	if r.cfg.VersionAttribute != "" {
+		read, err := expression.Version(av, r.cfg.VersionAttribute)
+		if err != nil {
+			return r.log.WrapError(err, "Error leyendo la versión del item")
+		}
+		expression.SetVersion(av, r.cfg.VersionAttribute, read+1)
+		e, err := expression.Build(nil, expression.VersionCondition(r.cfg.VersionAttribute, read))
+		if err != nil {
+			return r.log.WrapError(err, "Error armando la condición")
+		}
+		input.ConditionExpression = e.Condition
+		input.ExpressionAttributeNames = e.Names
+		input.ExpressionAttributeValues = e.Values
+	}
+	_, err = r.client.PutItem(ctx, input)
+	if err != nil {
+		return r.log.WrapError(expression.VersionConflict(err), "Error al guardar el item")
+	}
+	if r.cfg.VersionAttribute != "" {
+		if err := attributevalue.UnmarshalMap(av, item); err != nil {
+			return r.log.WrapError(err, "Error actualizando la versión del item")
+		}
+	}
+	return nil
+}
+
+func (r *Repository[T]) Delete(ctx context.Context, key Key) error {
+	k, err := r.key(key)
+	if err != nil {
+		return r.log.WrapError(err, "error serializando clave de búsqueda")
+	}
+	_, err = r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.cfg.Table),
+		Key:       k,
+	})
+	if err != nil {
+		return r.log.WrapError(err, "error al eliminar el item")
+	}
+	return nil
+}
+
+// Query recorre todas las páginas de la partición, hasta WithLimit si se indica.
+func (r *Repository[T]) Query(ctx context.Context, partitionKey interface{}, opts ...QueryOption) ([]T, error) {
+	o := queryOptions{partitionKey: r.cfg.PartitionKey, sortKey: r.cfg.SortKey}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	input, err := o.input(r.cfg.Table, partitionKey)
+	if err != nil {
+		return nil, r.log.WrapError(err, "error armando la consulta")
+	}
+
+	var items []T
+	for {
+		if o.limit > 0 {
+			input.Limit = aws.Int32(o.limit - int32(len(items)))
+		}
+		result, err := r.client.Query(ctx, input)
+		if err != nil {
+			return nil, r.log.WrapError(err, "error al ejecutar la consulta")
+		}
+		var page []T
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, r.log.WrapError(err, "error deserializando items")
+		}
+		items = append(items, page...)
+		if len(result.LastEvaluatedKey) == 0 || (o.limit > 0 && int32(len(items)) >= o.limit) {
+			return items, nil
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
+
+// BatchGet obtiene los items en lotes de 100 claves y reintenta las claves no procesadas.
+// El orden del resultado no corresponde al de keys y las claves inexistentes se omiten.
+// Si quedan claves sin procesar devuelve los items leídos junto con un *UnprocessedKeysError.
+func (r *Repository[T]) BatchGet(ctx context.Context, keys []Key) ([]T, error) {
+	var items []T
+	var unprocessed []map[string]types.AttributeValue
+	for start := 0; start < len(keys); start += maxBatchGetKeys {
+		end := min(start+maxBatchGetKeys, len(keys))
+		chunk := make([]map[string]types.AttributeValue, 0, end-start)
+		for _, key := range keys[start:end] {
+			k, err := r.key(key)
+			if err != nil {
+				return nil, r.log.WrapError(err, "error serializando clave de búsqueda")
+			}
+			chunk = append(chunk, k)
+		}
+		page, pending, err := r.batchGet(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		unprocessed = append(unprocessed, pending...)
+	}
+	if len(unprocessed) > 0 {
+		return items, &UnprocessedKeysError{Table: r.cfg.Table, Keys: unprocessed}
+	}
+	return items, nil
+}
+
+func (r *Repository[T]) batchGet(ctx context.Context, keys []map[string]types.AttributeValue) ([]T, []map[string]types.AttributeValue, error) {
+	input := &dynamodb.BatchGetItemInput{
+		RequestItems: map[string]types.KeysAndAttributes{
+			r.cfg.Table: {Keys: keys},
+		},
+	}
+	var items []T
+	for attempt := 0; ; attempt++ {
+		output, err := r.client.BatchGetItem(ctx, input)
+		if err != nil {
+			return nil, nil, r.log.WrapError(err, "error al ejecutar BatchGetItem")
+		}
+		var page []T
+		if err := attributevalue.UnmarshalListOfMaps(output.Responses[r.cfg.Table], &page); err != nil {
+			return nil, nil, r.log.WrapError(err, "error deserializando items")
+		}
+		items = append(items, page...)
+		if len(output.UnprocessedKeys) == 0 {
+			return items, nil, nil
+		}
+		if attempt+1 >= batchGetAttempts {
+			return items, output.UnprocessedKeys[r.cfg.Table].Keys, nil
+		}
+		input.RequestItems = output.UnprocessedKeys
+		if !messaging.Backoff(ctx, batchGetBackoff, attempt) {
+			return nil, nil, ctx.Err()
+		}
+	}
+}
+
+func (e *UnprocessedKeysError) Error() string {
+	return fmt.Sprintf("repository %s: %d keys unprocessed after retries: %v", e.Table, len(e.Keys), e.keys())
+}
+
+func (e *UnprocessedKeysError) keys() []map[string]interface{} {
+	keys := make([]map[string]interface{}, 0, len(e.Keys))
+	for _, k := range e.Keys {
+		var key map[string]interface{}
+		if err := attributevalue.UnmarshalMap(k, &key); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (r *Repository[T]) key(k Key) (map[string]types.AttributeValue, error) {
+	if r.cfg.PartitionKey == "" {
+		return nil, fmt.Errorf("repository %s: partition key not configured", r.cfg.Table)
+	}
+	pk, err := attributevalue.Marshal(k.PartitionKey)
+	if err != nil {
+		return nil, err
+	}
+	key := map[string]types.AttributeValue{r.cfg.PartitionKey: pk}
+	if r.cfg.SortKey == "" {
+		return key, nil
+	}
+	if k.SortKey == nil {
+		return nil, fmt.Errorf("repository %s: sort key %q required", r.cfg.Table, r.cfg.SortKey)
+	}
+	sk, err := attributevalue.Marshal(k.SortKey)
+	if err != nil {
+		return nil, err
+	}
+	key[r.cfg.SortKey] = sk
+	return key, nil
+}
+
+func (o queryOptions) input(table string, partitionKey interface{}) (*dynamodb.QueryInput, error) {
+	if o.partitionKey == "" {
+		return nil, fmt.Errorf("repository %s: partition key not configured", table)
+	}
+	pk, err := attributevalue.Marshal(partitionKey)
+	if err != nil {
+		return nil, err
+	}
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(table),
+		KeyConditionExpression:    aws.String("#pk = :pk"),
+		ExpressionAttributeNames:  map[string]string{"#pk": o.partitionKey},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":pk": pk},
+		ScanIndexForward:          aws.Bool(!o.descending),
+	}
+	if o.index != "" {
+		input.IndexName = aws.String(o.index)
+	}
+	if o.operator == "" {
+		return input, nil
+	}
+	if o.sortKey == "" {
+		return nil, fmt.Errorf("repository %s: sort key condition without sort key", table)
+	}
+	input.ExpressionAttributeNames["#sk"] = o.sortKey
+	for i, v := range o.values {
+		av, err := attributevalue.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		input.ExpressionAttributeValues[fmt.Sprintf(":sk%d", i)] = av
+	}
+	switch o.operator {
+	case "begins_with":
+		input.KeyConditionExpression = aws.String("#pk = :pk AND begins_with(#sk, :sk0)")
+	case "between":
+		input.KeyConditionExpression = aws.String("#pk = :pk AND #sk BETWEEN :sk0 AND :sk1")
+	}
+	return input, nil
+}
@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/dynamo/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+type account struct {
+	PK      string `dynamodbav:"PK"`
+	SK      string `dynamodbav:"SK"`
+	Balance int    `dynamodbav:"balance"`
+}
+
+var accountsConfig = Config{Table: "accounts", PartitionKey: "PK", SortKey: "SK"}
+
+func marshal(t *testing.T, items ...account) []map[string]types.AttributeValue {
+	out := make([]map[string]types.AttributeValue, 0, len(items))
+	for _, i := range items {
+		av, err := attributevalue.MarshalMap(i)
+		assert.NoError(t, err)
+		out = append(out, av)
+	}
+	return out
+}
+
+func TestGetSuccess(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("GetItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.GetItemInput) bool {
+		return aws.ToString(in.TableName) == "accounts" &&
+			in.Key["PK"].(*types.AttributeValueMemberS).Value == "user:1" &&
+			in.Key["SK"].(*types.AttributeValueMemberS).Value == "account:ars"
+	})).Return(&dynamodb.GetItemOutput{Item: marshal(t, account{PK: "user:1", SK: "account:ars", Balance: 10})[0]}, nil)
+
+	repo := NewRepository[account](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, accountsConfig)
+	got, err := repo.Get(context.TODO(), Key{PartitionKey: "user:1", SortKey: "account:ars"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, account{PK: "user:1", SK: "account:ars", Balance: 10}, got)
+}
+
+func TestGetNotFound(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+	l.On("WrapError", ErrNotFound, "item no encontrado").Return(ErrNotFound)
+
+	repo := NewRepository[account](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, accountsConfig)
+	_, err := repo.Get(context.TODO(), Key{PartitionKey: "user:1", SortKey: "account:ars"})
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetRequiresSortKey(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	l.On("WrapError", mock.Anything, "error serializando clave de búsqueda").
+		Return(errors.New("error serializando clave de búsqueda"))
+
+	repo := NewRepository[account](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, accountsConfig)
+	_, err := repo.Get(context.TODO(), Key{PartitionKey: "user:1"})
+
+	assert.Error(t, err)
+}
+
+func TestPutAndDelete(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+		return in.Item["balance"].(*types.AttributeValueMemberN).Value == "10"
+	})).Return(&dynamodb.PutItemOutput{}, nil)
+	cli.On("DeleteItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.DeleteItemInput) bool {
+		return len(in.Key) == 2
+	})).Return(&dynamodb.DeleteItemOutput{}, nil)
+
+	repo := NewRepository[account](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, accountsConfig)
+
+	assert.NoError(t, repo.Put(context.TODO(), &account{PK: "user:1", SK: "account:ars", Balance: 10}))
+	assert.NoError(t, repo.Delete(context.TODO(), Key{PartitionKey: "user:1", SortKey: "account:ars"}))
+}
+
+type versionedAccount struct {
+	PK      string `dynamodbav:"PK"`
+	Version int64  `dynamodbav:"version,omitempty"`
+}
+
+func TestPutVersionedConflict(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+		return aws.ToString(in.ConditionExpression) == "#n0 = :v0" &&
+			in.ExpressionAttributeValues[":v0"].(*types.AttributeValueMemberN).Value == "7" &&
+			in.Item["version"].(*types.AttributeValueMemberN).Value == "8"
+	})).Return(nil, &types.ConditionalCheckFailedException{Message: aws.String("conditional request failed")})
+	l.On("WrapError", mock.Anything, "Error al guardar el item").
+		Return(func(err error, msg string) error { return errors.Join(errors.New(msg), err) })
+
+	repo := NewRepository[versionedAccount](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l},
+		Config{Table: "accounts", PartitionKey: "PK", VersionAttribute: "version"})
+	err := repo.Put(context.TODO(), &versionedAccount{PK: "user:1", Version: 7})
+
+	assert.ErrorIs(t, err, expression.ErrVersionConflict)
+}
+
+func TestPutVersionedNewItem(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+		return aws.ToString(in.ConditionExpression) == "attribute_not_exists(#n0)" &&
+			in.Item["version"].(*types.AttributeValueMemberN).Value == "1"
+	})).Return(&dynamodb.PutItemOutput{}, nil)
+
+	repo := NewRepository[versionedAccount](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l},
+		Config{Table: "accounts", PartitionKey: "PK", VersionAttribute: "version"})
+
+	assert.NoError(t, repo.Put(context.TODO(), &versionedAccount{PK: "user:1"}))
+}
+
+func TestPutVersionedTwiceWithSameValue(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+		return aws.ToString(in.ConditionExpression) == "attribute_not_exists(#n0)" &&
+			in.Item["version"].(*types.AttributeValueMemberN).Value == "1"
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	cli.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+		return aws.ToString(in.ConditionExpression) == "#n0 = :v0" &&
+			in.ExpressionAttributeValues[":v0"].(*types.AttributeValueMemberN).Value == "1" &&
+			in.Item["version"].(*types.AttributeValueMemberN).Value == "2"
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	repo := NewRepository[versionedAccount](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l},
+		Config{Table: "accounts", PartitionKey: "PK", VersionAttribute: "version"})
+	acc := versionedAccount{PK: "user:1"}
+
+	assert.NoError(t, repo.Put(context.TODO(), &acc))
+	assert.Equal(t, int64(1), acc.Version)
+	assert.NoError(t, repo.Put(context.TODO(), &acc))
+	assert.Equal(t, int64(2), acc.Version)
+}
+
+func TestQueryPagesUntilLimit(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	var inputs []dynamodb.QueryInput
+	cli.On("Query", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { inputs = append(inputs, *args.Get(1).(*dynamodb.QueryInput)) }).
+		Return(&dynamodb.QueryOutput{
+			Items:            marshal(t, account{PK: "user:1", SK: "account:ars"}, account{PK: "user:1", SK: "account:usd"}),
+			LastEvaluatedKey: map[string]types.AttributeValue{"PK": &types.AttributeValueMemberS{Value: "user:1"}},
+		}, nil).Once()
+	cli.On("Query", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { inputs = append(inputs, *args.Get(1).(*dynamodb.QueryInput)) }).
+		Return(&dynamodb.QueryOutput{
+			Items:            marshal(t, account{PK: "user:1", SK: "account:brl"}),
+			LastEvaluatedKey: map[string]types.AttributeValue{"PK": &types.AttributeValueMemberS{Value: "user:1"}},
+		}, nil).Once()
+
+	repo := NewRepository[account](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, accountsConfig)
+	got, err := repo.Query(context.TODO(), "user:1", BeginsWith("account:"), WithLimit(3), Descending())
+
+	assert.NoError(t, err)
+	assert.Len(t, got, 3)
+	assert.Len(t, inputs, 2)
+	assert.Equal(t, "#pk = :pk AND begins_with(#sk, :sk0)", aws.ToString(inputs[0].KeyConditionExpression))
+	assert.False(t, aws.ToBool(inputs[0].ScanIndexForward))
+	assert.Equal(t, int32(3), aws.ToInt32(inputs[0].Limit))
+	assert.Equal(t, int32(1), aws.ToInt32(inputs[1].Limit))
+	assert.NotNil(t, inputs[1].ExclusiveStartKey)
+}
+
+func TestQueryWithIndex(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return aws.ToString(in.IndexName) == "by-status" &&
+			in.ExpressionAttributeNames["#pk"] == "status" &&
+			in.ExpressionAttributeNames["#sk"] == "created_at" &&
+			aws.ToString(in.KeyConditionExpression) == "#pk = :pk AND #sk BETWEEN :sk0 AND :sk1"
+	})).Return(&dynamodb.QueryOutput{Items: marshal(t, account{PK: "user:1"})}, nil)
+
+	repo := NewRepository[account](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, accountsConfig)
+	got, err := repo.Query(context.TODO(), "ACTIVE",
+		WithIndex("by-status", "status", "created_at"), Between("2024-01-01", "2024-12-31"))
+
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+}
+
+func TestBatchGetChunksAndRetriesUnprocessed(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	keys := make([]Key, 0, 150)
+	for i := 0; i < 150; i++ {
+		keys = append(keys, Key{PartitionKey: "user:1", SortKey: i})
+	}
+	unprocessed := map[string]types.KeysAndAttributes{"accounts": {Keys: marshal(t, account{PK: "user:1"})}}
+
+	cli.On("BatchGetItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.BatchGetItemInput) bool {
+		return len(in.RequestItems["accounts"].Keys) == 100
+	})).Return(&dynamodb.BatchGetItemOutput{
+		Responses:       map[string][]map[string]types.AttributeValue{"accounts": marshal(t, account{PK: "a"})},
+		UnprocessedKeys: unprocessed,
+	}, nil).Once()
+	cli.On("BatchGetItem", mock.Anything, mock.Anything).Return(&dynamodb.BatchGetItemOutput{
+		Responses: map[string][]map[string]types.AttributeValue{"accounts": marshal(t, account{PK: "b"})},
+	}, nil).Twice()
+
+	repo := NewRepository[account](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, accountsConfig)
+	got, err := repo.BatchGet(context.TODO(), keys)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []account{{PK: "a"}, {PK: "b"}, {PK: "b"}}, got)
+}
+
+func TestBatchGetReturnsUnprocessedKeys(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	unprocessed := map[string]types.KeysAndAttributes{"accounts": {Keys: marshal(t, account{PK: "user:1", SK: "b"})}}
+	cli.On("BatchGetItem", mock.Anything, mock.Anything).Return(&dynamodb.BatchGetItemOutput{
+		Responses:       map[string][]map[string]types.AttributeValue{"accounts": marshal(t, account{PK: "user:1", SK: "a"})},
+		UnprocessedKeys: unprocessed,
+	}, nil).Once()
+	cli.On("BatchGetItem", mock.Anything, mock.Anything).Return(&dynamodb.BatchGetItemOutput{
+		UnprocessedKeys: unprocessed,
+	}, nil).Times(batchGetAttempts - 1)
+
+	repo := NewRepository[account](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, accountsConfig)
+	got, err := repo.BatchGet(context.TODO(), []Key{{PartitionKey: "user:1", SortKey: "a"}, {PartitionKey: "user:1", SortKey: "b"}})
+
+	var unprocessedErr *UnprocessedKeysError
+	assert.ErrorAs(t, err, &unprocessedErr)
+	assert.Equal(t, unprocessed["accounts"].Keys, unprocessedErr.Keys)
+	assert.Contains(t, err.Error(), "SK:b")
+	assert.Equal(t, []account{{PK: "user:1", SK: "a"}}, got)
+}
+
+func TestBatchGetStopsWhenContextCanceled(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("BatchGetItem", mock.Anything, mock.Anything).Return(&dynamodb.BatchGetItemOutput{
+		UnprocessedKeys: map[string]types.KeysAndAttributes{"accounts": {Keys: marshal(t, account{PK: "user:1"})}},
+	}, nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	repo := NewRepository[account](Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, accountsConfig)
+	_, err := repo.BatchGet(ctx, []Key{{PartitionKey: "user:1", SortKey: "a"}})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
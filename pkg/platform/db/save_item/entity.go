@@ -4,15 +4,20 @@ import (
 	"context"
 
 	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
 
 	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
 )
 
 type Service interface {
-	Accept(ctx context.Context, itm map[string]interface{}, table string) error
+	Accept(ctx context.Context, itm map[string]interface{}, table string, conditions ...expression.Condition) error
 }
 
+// Dependencies.VersionAttribute activa el optimistic locking: el item debe traer en ese atributo
+// la versión leída (0 o ausente si es nuevo), la escritura se condiciona a que no haya cambiado
+// y se guarda incrementada. Si otro proceso lo modificó se devuelve expression.ErrVersionConflict.
 type Dependencies struct {
-	Client *dynamo.Dynamo
-	Log    log.Service
+	Client           *dynamo.Dynamo
+	Log              log.Service
+	VersionAttribute string
 }
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	expression "github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -13,17 +14,24 @@ type Service struct {
 	mock.Mock
 }
 
-// Accept provides a mock function with given fields: ctx, itm, table
-func (_m *Service) Accept(ctx context.Context, itm map[string]interface{}, table string) error {
-	ret := _m.Called(ctx, itm, table)
+// Accept provides a mock function with given fields: ctx, itm, table, conditions
+func (_m *Service) Accept(ctx context.Context, itm map[string]interface{}, table string, conditions ...expression.Condition) error {
+	_va := make([]interface{}, len(conditions))
+	for _i := range conditions {
+		_va[_i] = conditions[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, itm, table)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Accept")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, string) error); ok {
-		r0 = rf(ctx, itm, table)
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, string, ...expression.Condition) error); ok {
+		r0 = rf(ctx, itm, table, conditions...)
 	} else {
 		r0 = ret.Error(0)
 	}
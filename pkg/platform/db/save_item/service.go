@@ -7,34 +7,65 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
 	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
 )
 
 type service struct {
-	client *dynamo.Dynamo
-	log    log.Service
+	client  *dynamo.Dynamo
+	log     log.Service
+	version string
 }
 
 var _ Service = (*service)(nil)
 
 func NewService(d Dependencies) *service {
 	return &service{
-		client: d.Client,
-		log:    d.Log,
+		client:  d.Client,
+		log:     d.Log,
+		version: d.VersionAttribute,
 	}
 }
 
-func (s *service) Accept(ctx context.Context, itm map[string]interface{}, table string) error {
+// Accept guarda el item; con conditions (p.ej. expression.AttributeNotExists("PK") para
+// put-if-absent) solo se escribe si se cumplen todas. Con versionado, al guardar
+// itm queda con la nueva versión.
+func (s *service) Accept(ctx context.Context, itm map[string]interface{}, table string, conditions ...expression.Condition) error {
 	item, err := attributevalue.MarshalMap(itm)
 	if err != nil {
 		return s.log.WrapError(err, "Error serializando item")
 	}
-	_, err = s.client.Cliente.PutItem(ctx, &dynamodb.PutItemInput{
+	input := &dynamodb.PutItemInput{
 		TableName: &table,
 		Item:      item,
-	})
+	}
+	var next int64
+	translate := expression.ConditionFailed
+	if s.version != "" {
+		read, err := expression.Version(item, s.version)
+		if err != nil {
+			return s.log.WrapError(err, "Error leyendo la versión del item")
+		}
+		next = read + 1
+		expression.SetVersion(item, s.version, next)
+		conditions = append([]expression.Condition{expression.VersionCondition(s.version, read)}, conditions...)
+		translate = expression.VersionConflict
+	}
+	if len(conditions) > 0 {
+		e, err := expression.Build(nil, expression.And(conditions...))
+		if err != nil {
+			return s.log.WrapError(err, "Error armando la condición")
+		}
+		input.ConditionExpression = e.Condition
+		input.ExpressionAttributeNames = e.Names
+		input.ExpressionAttributeValues = e.Values
+	}
+	_, err = s.client.Cliente.PutItem(ctx, input)
 	if err != nil {
-		return s.log.WrapError(err, "Error al guardar el item")
+		return s.log.WrapError(translate(err), "Error al guardar el item")
+	}
+	if s.version != "" {
+		itm[s.version] = next
 	}
 	return nil
 }
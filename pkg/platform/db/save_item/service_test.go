@@ -3,13 +3,17 @@ package save_item
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
 	cl "github.com/uala-challenge/simple-toolkit/pkg/client/dynamo/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
 	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
 )
 
@@ -50,3 +54,67 @@ func TestAcceptSuccess(t *testing.T) {
 	assert.NoError(t, err)
 	cli.AssertExpectations(t)
 }
+
+func TestAcceptPutIfAbsent(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+		return aws.ToString(in.ConditionExpression) == "attribute_not_exists(#n0)" && in.ExpressionAttributeNames["#n0"] == "PK"
+	})).Return(nil, &types.ConditionalCheckFailedException{Message: aws.String("conditional request failed")})
+	l.On("WrapError", mock.Anything, "Error al guardar el item").
+		Return(func(err error, msg string) error { return fmt.Errorf("%s: %w", msg, err) })
+
+	cliente := NewService(Dependencies{
+		Client: &dynamo.Dynamo{Cliente: cli},
+		Log:    l,
+	})
+
+	err := cliente.Accept(context.TODO(), map[string]interface{}{"PK": "123"}, "table", expression.AttributeNotExists("PK"))
+	assert.ErrorIs(t, err, expression.ErrConditionFailed)
+}
+
+func TestAcceptVersionedIncrementsVersion(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+		return aws.ToString(in.ConditionExpression) == "#n0 = :v0" &&
+			in.ExpressionAttributeValues[":v0"].(*types.AttributeValueMemberN).Value == "2" &&
+			in.Item["version"].(*types.AttributeValueMemberN).Value == "3"
+	})).Return(&dynamodb.PutItemOutput{}, nil)
+
+	cliente := NewService(Dependencies{
+		Client:           &dynamo.Dynamo{Cliente: cli},
+		Log:              l,
+		VersionAttribute: "version",
+	})
+
+	itm := map[string]interface{}{"PK": "123", "version": 2}
+	err := cliente.Accept(context.TODO(), itm, "table")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), itm["version"])
+}
+
+func TestAcceptVersionedConflict(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("PutItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.PutItemInput) bool {
+		return aws.ToString(in.ConditionExpression) == "attribute_not_exists(#n0)" &&
+			in.Item["version"].(*types.AttributeValueMemberN).Value == "1"
+	})).Return(nil, &types.ConditionalCheckFailedException{Message: aws.String("conditional request failed")})
+	l.On("WrapError", mock.Anything, "Error al guardar el item").
+		Return(func(err error, msg string) error { return fmt.Errorf("%s: %w", msg, err) })
+
+	cliente := NewService(Dependencies{
+		Client:           &dynamo.Dynamo{Cliente: cli},
+		Log:              l,
+		VersionAttribute: "version",
+	})
+
+	itm := map[string]interface{}{"PK": "123"}
+	err := cliente.Accept(context.TODO(), itm, "table")
+	assert.ErrorIs(t, err, expression.ErrVersionConflict)
+	assert.NotContains(t, itm, "version")
+}
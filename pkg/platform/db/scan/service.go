@@ -0,0 +1,45 @@
+package scan
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+)
+
+type service struct {
+	client *dynamo.Dynamo
+	log    log.Service
+}
+
+var _ Service = (*service)(nil)
+
+func NewService(d Dependencies) *service {
+	return &service{
+		client: d.Client,
+		log:    d.Log,
+	}
+}
+
+// Apply recorre todas las páginas del scan sin modificar input.
+func (s *service) Apply(ctx context.Context, input *dynamodb.ScanInput) ([]map[string]types.AttributeValue, error) {
+	in := *input
+	var results []map[string]types.AttributeValue
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, s.log.WrapError(err, "error al ejecutar Scan")
+		}
+		output, err := s.client.Cliente.Scan(ctx, &in)
+		if err != nil {
+			return nil, s.log.WrapError(err, "error al ejecutar Scan")
+		}
+		results = append(results, output.Items...)
+		if len(output.LastEvaluatedKey) == 0 {
+			break
+		}
+		in.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+	return results, nil
+}
@@ -0,0 +1,50 @@
+package scan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/dynamo/mock"
+	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+func TestScanAllPages(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	first := map[string]types.AttributeValue{"PK": &types.AttributeValueMemberS{Value: "1"}}
+	second := map[string]types.AttributeValue{"PK": &types.AttributeValueMemberS{Value: "2"}}
+	cli.On("Scan", mock.Anything, mock.Anything).Return(&dynamodb.ScanOutput{Items: []map[string]types.AttributeValue{first}, LastEvaluatedKey: first}, nil).Once()
+	cli.On("Scan", mock.Anything, mock.MatchedBy(func(in *dynamodb.ScanInput) bool {
+		return in.ExclusiveStartKey != nil
+	})).Return(&dynamodb.ScanOutput{Items: []map[string]types.AttributeValue{second}}, nil).Once()
+
+	input := &dynamodb.ScanInput{TableName: aws.String("table")}
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	rps, err := cliente.Apply(context.TODO(), input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]types.AttributeValue{first, second}, rps)
+	assert.Nil(t, input.ExclusiveStartKey)
+}
+
+func TestScanError(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("Scan", mock.Anything, mock.Anything).Return(nil, errors.New("error de conexión con DynamoDB"))
+	l.On("WrapError", mock.Anything, "error al ejecutar Scan").Return(errors.New("error al ejecutar Scan"))
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	rps, err := cliente.Apply(context.TODO(), &dynamodb.ScanInput{TableName: aws.String("table")})
+
+	assert.Error(t, err)
+	assert.Nil(t, rps)
+}
@@ -0,0 +1,125 @@
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+)
+
+func NewTransaction() *Transaction {
+	return &Transaction{}
+}
+
+// WithClientToken hace la transacción idempotente: DynamoDB ignora los reintentos con el
+// mismo token durante 10 minutos.
+func (t *Transaction) WithClientToken(token string) *Transaction {
+	t.clientToken = token
+	return t
+}
+
+// Put guarda item (un struct con tags dynamodbav o un map) si se cumplen las condiciones.
+func (t *Transaction) Put(table string, item interface{}, conditions ...expression.Condition) *Transaction {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return t.fail(KindPut, table, err)
+	}
+	e, err := build(nil, conditions)
+	if err != nil {
+		return t.fail(KindPut, table, err)
+	}
+	return t.add(KindPut, table, types.TransactWriteItem{Put: &types.Put{
+		TableName:                 aws.String(table),
+		Item:                      av,
+		ConditionExpression:       e.Condition,
+		ExpressionAttributeNames:  e.Names,
+		ExpressionAttributeValues: e.Values,
+	}})
+}
+
+func (t *Transaction) Update(table string, key map[string]interface{}, update *expression.Update, conditions ...expression.Condition) *Transaction {
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return t.fail(KindUpdate, table, err)
+	}
+	e, err := build(update, conditions)
+	if err != nil {
+		return t.fail(KindUpdate, table, err)
+	}
+	return t.add(KindUpdate, table, types.TransactWriteItem{Update: &types.Update{
+		TableName:                 aws.String(table),
+		Key:                       k,
+		UpdateExpression:          e.Update,
+		ConditionExpression:       e.Condition,
+		ExpressionAttributeNames:  e.Names,
+		ExpressionAttributeValues: e.Values,
+	}})
+}
+
+func (t *Transaction) Delete(table string, key map[string]interface{}, conditions ...expression.Condition) *Transaction {
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return t.fail(KindDelete, table, err)
+	}
+	e, err := build(nil, conditions)
+	if err != nil {
+		return t.fail(KindDelete, table, err)
+	}
+	return t.add(KindDelete, table, types.TransactWriteItem{Delete: &types.Delete{
+		TableName:                 aws.String(table),
+		Key:                       k,
+		ConditionExpression:       e.Condition,
+		ExpressionAttributeNames:  e.Names,
+		ExpressionAttributeValues: e.Values,
+	}})
+}
+
+// ConditionCheck valida un item que no se modifica; si la condición falla se cancela toda la transacción.
+func (t *Transaction) ConditionCheck(table string, key map[string]interface{}, condition expression.Condition, more ...expression.Condition) *Transaction {
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return t.fail(KindConditionCheck, table, err)
+	}
+	e, err := build(nil, append([]expression.Condition{condition}, more...))
+	if err != nil {
+		return t.fail(KindConditionCheck, table, err)
+	}
+	return t.add(KindConditionCheck, table, types.TransactWriteItem{ConditionCheck: &types.ConditionCheck{
+		TableName:                 aws.String(table),
+		Key:                       k,
+		ConditionExpression:       e.Condition,
+		ExpressionAttributeNames:  e.Names,
+		ExpressionAttributeValues: e.Values,
+	}})
+}
+
+// Operations devuelve las operaciones en el orden en que se agregaron.
+func (t *Transaction) Operations() []Operation {
+	return append([]Operation(nil), t.operations...)
+}
+
+func (t *Transaction) add(kind, table string, item types.TransactWriteItem) *Transaction {
+	t.operations = append(t.operations, Operation{Index: len(t.items), Kind: kind, Table: table})
+	t.items = append(t.items, item)
+	return t
+}
+
+func (t *Transaction) fail(kind, table string, err error) *Transaction {
+	if t.err == nil {
+		t.err = fmt.Errorf("transaction: %s on %s (operation %d): %w", kind, table, len(t.items), err)
+	}
+	return t
+}
+
+func build(update *expression.Update, conditions []expression.Condition) (expression.Expression, error) {
+	var condition expression.Condition
+	if len(conditions) > 0 {
+		condition = expression.And(conditions...)
+	}
+	if update == nil && condition == nil {
+		return expression.Expression{}, nil
+	}
+	return expression.Build(update, condition)
+}
@@ -0,0 +1,70 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+)
+
+const (
+	MaxOperations = 100
+
+	KindPut            = "Put"
+	KindUpdate         = "Update"
+	KindDelete         = "Delete"
+	KindConditionCheck = "ConditionCheck"
+	KindGet            = "Get"
+)
+
+// ErrTransactionConflict indica que otra transacción estaba modificando alguno de los items.
+var ErrTransactionConflict = errors.New("transaction conflict")
+
+type Service interface {
+	Write(ctx context.Context, tx *Transaction) error
+	Get(ctx context.Context, gets ...GetItem) ([]map[string]types.AttributeValue, error)
+}
+
+// Transaction acumula operaciones sobre una o varias tablas que se ejecutan de forma atómica.
+type Transaction struct {
+	items       []types.TransactWriteItem
+	operations  []Operation
+	clientToken string
+	err         error
+}
+
+// Operation identifica una operación dentro de la transacción.
+type Operation struct {
+	Index int
+	Kind  string
+	Table string
+}
+
+// GetItem es una lectura dentro de TransactGetItems.
+type GetItem struct {
+	Table string
+	Key   map[string]interface{}
+}
+
+// OperationError describe por qué una operación canceló la transacción. Con Code
+// "ConditionalCheckFailed" cumple errors.Is(err, expression.ErrConditionFailed) y con
+// "TransactionConflict" errors.Is(err, ErrTransactionConflict).
+type OperationError struct {
+	Operation
+	Code    string
+	Message string
+	Item    map[string]types.AttributeValue
+}
+
+// CanceledError reúne las operaciones que cancelaron la transacción.
+type CanceledError struct {
+	Operations []*OperationError
+	Err        error
+}
+
+type Dependencies struct {
+	Client *dynamo.Dynamo
+	Log    log.Service
+}
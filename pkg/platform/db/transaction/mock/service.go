@@ -0,0 +1,86 @@
+// Code generated by mockery v2.52.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	transaction "github.com/uala-challenge/simple-toolkit/pkg/platform/db/transaction"
+
+	types "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, gets
+func (_m *Service) Get(ctx context.Context, gets ...transaction.GetItem) ([]map[string]types.AttributeValue, error) {
+	_va := make([]interface{}, len(gets))
+	for _i := range gets {
+		_va[_i] = gets[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []map[string]types.AttributeValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...transaction.GetItem) ([]map[string]types.AttributeValue, error)); ok {
+		return rf(ctx, gets...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...transaction.GetItem) []map[string]types.AttributeValue); ok {
+		r0 = rf(ctx, gets...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]map[string]types.AttributeValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...transaction.GetItem) error); ok {
+		r1 = rf(ctx, gets...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Write provides a mock function with given fields: ctx, tx
+func (_m *Service) Write(ctx context.Context, tx *transaction.Transaction) error {
+	ret := _m.Called(ctx, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Write")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *transaction.Transaction) error); ok {
+		r0 = rf(ctx, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
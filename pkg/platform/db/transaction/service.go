@@ -0,0 +1,151 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+)
+
+type service struct {
+	client *dynamo.Dynamo
+	log    log.Service
+}
+
+var _ Service = (*service)(nil)
+
+func NewService(d Dependencies) *service {
+	return &service{
+		client: d.Client,
+		log:    d.Log,
+	}
+}
+
+// Write ejecuta la transacción con TransactWriteItems. Si DynamoDB la cancela devuelve un
+// *CanceledError con el motivo de cada operación que falló.
+func (s *service) Write(ctx context.Context, tx *Transaction) error {
+	if tx.err != nil {
+		return s.log.WrapError(tx.err, "error armando la transacción")
+	}
+	if len(tx.items) == 0 || len(tx.items) > MaxOperations {
+		return s.log.WrapError(fmt.Errorf("transaction: %d operations, expected 1 to %d", len(tx.items), MaxOperations), "error armando la transacción")
+	}
+	input := &dynamodb.TransactWriteItemsInput{TransactItems: tx.items}
+	if tx.clientToken != "" {
+		input.ClientRequestToken = aws.String(tx.clientToken)
+	}
+	_, err := s.client.Cliente.TransactWriteItems(ctx, input)
+	if err != nil {
+		return s.log.WrapError(canceled(err, tx.operations), "error al ejecutar la transacción")
+	}
+	return nil
+}
+
+// Get lee los items con TransactGetItems; el resultado respeta el orden de gets y
+// tiene nil para los items inexistentes.
+func (s *service) Get(ctx context.Context, gets ...GetItem) ([]map[string]types.AttributeValue, error) {
+	if len(gets) == 0 || len(gets) > MaxOperations {
+		return nil, s.log.WrapError(fmt.Errorf("transaction: %d reads, expected 1 to %d", len(gets), MaxOperations), "error armando la lectura transaccional")
+	}
+	items := make([]types.TransactGetItem, 0, len(gets))
+	operations := make([]Operation, 0, len(gets))
+	for i, g := range gets {
+		k, err := attributevalue.MarshalMap(g.Key)
+		if err != nil {
+			return nil, s.log.WrapError(err, "error serializando clave")
+		}
+		items = append(items, types.TransactGetItem{Get: &types.Get{TableName: aws.String(g.Table), Key: k}})
+		operations = append(operations, Operation{Index: i, Kind: KindGet, Table: g.Table})
+	}
+	output, err := s.client.Cliente.TransactGetItems(ctx, &dynamodb.TransactGetItemsInput{TransactItems: items})
+	if err != nil {
+		return nil, s.log.WrapError(canceled(err, operations), "error al ejecutar la lectura transaccional")
+	}
+	results := make([]map[string]types.AttributeValue, len(gets))
+	for i, r := range output.Responses {
+		if i < len(results) {
+			results[i] = r.Item
+		}
+	}
+	return results, nil
+}
+
+func canceled(err error, operations []Operation) error {
+	var tce *types.TransactionCanceledException
+	if !errors.As(err, &tce) {
+		return err
+	}
+	c := &CanceledError{Err: err}
+	for i, reason := range tce.CancellationReasons {
+		code := aws.ToString(reason.Code)
+		if code == "" || code == "None" {
+			continue
+		}
+		op := Operation{Index: i}
+		if i < len(operations) {
+			op = operations[i]
+		}
+		c.Operations = append(c.Operations, &OperationError{
+			Operation: op,
+			Code:      code,
+			Message:   aws.ToString(reason.Message),
+			Item:      reason.Item,
+		})
+	}
+	return c
+}
+
+func (e *OperationError) Error() string {
+	msg := fmt.Sprintf("%s on %s (operation %d): %s", e.Kind, e.Table, e.Index, e.Code)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *OperationError) Unwrap() error {
+	switch e.Code {
+	case "ConditionalCheckFailed":
+		return expression.ErrConditionFailed
+	case "TransactionConflict":
+		return ErrTransactionConflict
+	}
+	return nil
+}
+
+func (e *CanceledError) Error() string {
+	if len(e.Operations) == 0 {
+		return "transaction canceled: " + e.Err.Error()
+	}
+	parts := make([]string, 0, len(e.Operations))
+	for _, op := range e.Operations {
+		parts = append(parts, op.Error())
+	}
+	return "transaction canceled: " + strings.Join(parts, "; ")
+}
+
+func (e *CanceledError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Operations)+1)
+	for _, op := range e.Operations {
+		errs = append(errs, op)
+	}
+	return append(errs, e.Err)
+}
+
+// Failed devuelve el error de la operación index, o nil si no causó la cancelación.
+func (e *CanceledError) Failed(index int) *OperationError {
+	for _, op := range e.Operations {
+		if op.Index == index {
+			return op
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/dynamo/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+func transfer() *Transaction {
+	return NewTransaction().
+		WithClientToken("transfer-1").
+		Update("accounts", map[string]interface{}{"PK": "account:1"},
+			expression.NewUpdate().Add("balance", -100), expression.GreaterThanEqual("balance", 100)).
+		Update("accounts", map[string]interface{}{"PK": "account:2"},
+			expression.NewUpdate().Add("balance", 100)).
+		Put("movements", map[string]interface{}{"PK": "transfer:1"}, expression.AttributeNotExists("PK")).
+		ConditionCheck("users", map[string]interface{}{"PK": "user:1"}, expression.Equal("status", "ACTIVE"))
+}
+
+func TestWriteSuccess(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("TransactWriteItems", mock.Anything, mock.MatchedBy(func(in *dynamodb.TransactWriteItemsInput) bool {
+		return len(in.TransactItems) == 4 &&
+			aws.ToString(in.ClientRequestToken) == "transfer-1" &&
+			aws.ToString(in.TransactItems[0].Update.ConditionExpression) == "#n0 >= :v1" &&
+			in.TransactItems[1].Update.ConditionExpression == nil &&
+			in.TransactItems[2].Put != nil &&
+			in.TransactItems[3].ConditionCheck != nil
+	})).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+
+	assert.NoError(t, cliente.Write(context.TODO(), transfer()))
+}
+
+func TestWriteCanceledMapsReasons(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, &types.TransactionCanceledException{
+		Message: aws.String("Transaction cancelled"),
+		CancellationReasons: []types.CancellationReason{
+			{Code: aws.String("ConditionalCheckFailed"), Message: aws.String("The conditional request failed")},
+			{Code: aws.String("None")},
+			{Code: aws.String("None")},
+			{Code: aws.String("TransactionConflict")},
+		},
+	})
+	l.On("WrapError", mock.Anything, "error al ejecutar la transacción").
+		Return(func(err error, msg string) error { return err })
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	err := cliente.Write(context.TODO(), transfer())
+
+	var canceled *CanceledError
+	assert.ErrorAs(t, err, &canceled)
+	assert.Len(t, canceled.Operations, 2)
+	assert.ErrorIs(t, err, expression.ErrConditionFailed)
+	assert.ErrorIs(t, err, ErrTransactionConflict)
+
+	debit := canceled.Failed(0)
+	assert.Equal(t, Operation{Index: 0, Kind: KindUpdate, Table: "accounts"}, debit.Operation)
+	assert.ErrorIs(t, debit, expression.ErrConditionFailed)
+	assert.Nil(t, canceled.Failed(2))
+	assert.Equal(t, Operation{Index: 3, Kind: KindConditionCheck, Table: "users"}, canceled.Failed(3).Operation)
+}
+
+func TestWriteBuildError(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	l.On("WrapError", mock.Anything, "error armando la transacción").
+		Return(errors.New("error armando la transacción"))
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+
+	assert.Error(t, cliente.Write(context.TODO(), NewTransaction().Update("accounts", map[string]interface{}{"PK": "1"}, expression.NewUpdate())))
+	assert.Error(t, cliente.Write(context.TODO(), NewTransaction()))
+}
+
+func TestGetKeepsOrder(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	account := map[string]types.AttributeValue{"PK": &types.AttributeValueMemberS{Value: "account:1"}}
+	cli.On("TransactGetItems", mock.Anything, mock.MatchedBy(func(in *dynamodb.TransactGetItemsInput) bool {
+		return len(in.TransactItems) == 2 && aws.ToString(in.TransactItems[1].Get.TableName) == "users"
+	})).Return(&dynamodb.TransactGetItemsOutput{Responses: []types.ItemResponse{{Item: account}, {}}}, nil)
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	items, err := cliente.Get(context.TODO(),
+		GetItem{Table: "accounts", Key: map[string]interface{}{"PK": "account:1"}},
+		GetItem{Table: "users", Key: map[string]interface{}{"PK": "user:9"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]types.AttributeValue{account, nil}, items)
+}
@@ -0,0 +1,68 @@
+// Code generated by mockery v2.52.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	expression "github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+
+	types "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// Apply provides a mock function with given fields: ctx, key, table, update, conditions
+func (_m *Service) Apply(ctx context.Context, key map[string]interface{}, table string, update *expression.Update, conditions ...expression.Condition) (map[string]types.AttributeValue, error) {
+	_va := make([]interface{}, len(conditions))
+	for _i := range conditions {
+		_va[_i] = conditions[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key, table, update)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Apply")
+	}
+
+	var r0 map[string]types.AttributeValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, string, *expression.Update, ...expression.Condition) (map[string]types.AttributeValue, error)); ok {
+		return rf(ctx, key, table, update, conditions...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, string, *expression.Update, ...expression.Condition) map[string]types.AttributeValue); ok {
+		r0 = rf(ctx, key, table, update, conditions...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]types.AttributeValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]interface{}, string, *expression.Update, ...expression.Condition) error); ok {
+		r1 = rf(ctx, key, table, update, conditions...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
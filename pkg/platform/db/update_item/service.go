@@ -0,0 +1,57 @@
+package update_item
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+)
+
+type service struct {
+	client *dynamo.Dynamo
+	log    log.Service
+}
+
+var _ Service = (*service)(nil)
+
+func NewService(d Dependencies) *service {
+	return &service{
+		client: d.Client,
+		log:    d.Log,
+	}
+}
+
+// Apply aplica update sobre el item y devuelve el item actualizado. Con conditions la
+// actualización solo se aplica si se cumplen todas (compare-and-set); si no, el error
+// cumple errors.Is(err, expression.ErrConditionFailed).
+func (s *service) Apply(ctx context.Context, key map[string]interface{}, table string, update *expression.Update, conditions ...expression.Condition) (map[string]types.AttributeValue, error) {
+	k, err := attributevalue.MarshalMap(key)
+	if err != nil {
+		return nil, s.log.WrapError(err, "error serializando clave")
+	}
+	var condition expression.Condition
+	if len(conditions) > 0 {
+		condition = expression.And(conditions...)
+	}
+	e, err := expression.Build(update, condition)
+	if err != nil {
+		return nil, s.log.WrapError(err, "error armando la expresión de actualización")
+	}
+	output, err := s.client.Cliente.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 &table,
+		Key:                       k,
+		UpdateExpression:          e.Update,
+		ConditionExpression:       e.Condition,
+		ExpressionAttributeNames:  e.Names,
+		ExpressionAttributeValues: e.Values,
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if err != nil {
+		return nil, s.log.WrapError(expression.ConditionFailed(err), "error al actualizar el item")
+	}
+	return output.Attributes, nil
+}
@@ -0,0 +1,66 @@
+package update_item
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/dynamo/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/expression"
+	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+func TestUpdateCompareAndSet(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	updated := map[string]types.AttributeValue{"balance": &types.AttributeValueMemberN{Value: "150"}}
+	cli.On("UpdateItem", mock.Anything, mock.MatchedBy(func(in *dynamodb.UpdateItemInput) bool {
+		return aws.ToString(in.UpdateExpression) == "ADD #n0 :v0" &&
+			aws.ToString(in.ConditionExpression) == "#n0 = :v1" &&
+			in.ReturnValues == types.ReturnValueAllNew
+	})).Return(&dynamodb.UpdateItemOutput{Attributes: updated}, nil)
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	rps, err := cliente.Apply(context.TODO(), map[string]interface{}{"PK": "123"}, "table",
+		expression.NewUpdate().Add("balance", 50), expression.Equal("balance", 100))
+
+	assert.NoError(t, err)
+	assert.Equal(t, updated, rps)
+}
+
+func TestUpdateConditionFailed(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("UpdateItem", mock.Anything, mock.Anything).
+		Return(nil, &types.ConditionalCheckFailedException{Message: aws.String("conditional request failed")})
+	l.On("WrapError", mock.Anything, "error al actualizar el item").
+		Return(func(err error, msg string) error { return errors.Wrap(err, msg) })
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	rps, err := cliente.Apply(context.TODO(), map[string]interface{}{"PK": "123"}, "table",
+		expression.NewUpdate().Set("status", "CLOSED"), expression.Equal("status", "OPEN"))
+
+	assert.ErrorIs(t, err, expression.ErrConditionFailed)
+	assert.Nil(t, rps)
+}
+
+func TestUpdateEmptyExpression(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	l.On("WrapError", mock.Anything, "error armando la expresión de actualización").
+		Return(errors.New("error armando la expresión de actualización"))
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l})
+	_, err := cliente.Apply(context.TODO(), map[string]interface{}{"PK": "123"}, "table", expression.NewUpdate())
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/uala-challenge/simple-toolkit/pkg/client/sns"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/sqs"
+)
+
+// AttributeOutboxID viaja en cada mensaje para que los consumidores puedan deduplicar.
+const AttributeOutboxID = "outbox_id"
+
+// SNSDispatcher publica cada registro en el tópico indicado por Record.Destination (alias o ARN).
+func SNSDispatcher(p *sns.Publisher) Dispatcher {
+	return DispatcherFunc(func(ctx context.Context, r Record) error {
+		_, err := p.Publish(ctx, r.Destination, sns.Event{
+			Payload:         r.Payload,
+			EventType:       r.EventType,
+			Attributes:      withOutboxID(r),
+			GroupID:         r.GroupID,
+			DeduplicationID: r.ID,
+		})
+		return err
+	})
+}
+
+// SQSDispatcher envía cada registro a la cola del producer; Record.Destination se ignora.
+func SQSDispatcher(p *sqs.Producer) Dispatcher {
+	return DispatcherFunc(func(ctx context.Context, r Record) error {
+		attributes := withOutboxID(r)
+		if r.EventType != "" {
+			attributes[sns.AttributeEventType] = r.EventType
+		}
+		_, err := p.Send(ctx, sqs.Message{
+			Body:            r.Payload,
+			Attributes:      attributes,
+			GroupID:         r.GroupID,
+			DeduplicationID: r.ID,
+		})
+		return err
+	})
+}
+
+func withOutboxID(r Record) map[string]string {
+	attributes := make(map[string]string, len(r.Attributes)+2)
+	for k, v := range r.Attributes {
+		attributes[k] = v
+	}
+	attributes[AttributeOutboxID] = r.ID
+	return attributes
+}
@@ -0,0 +1,98 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+)
+
+const (
+	StatusPending       = "PENDING"
+	StatusSent          = "SENT"
+	StatusFailed        = "FAILED"
+	DefaultStatusIndex  = "status-index"
+	DefaultBatchSize    = 25
+	DefaultPollInterval = 5
+	DefaultMaxAttempts  = 10
+	DefaultRetryBackoff = 1
+	DefaultMaxBackoff   = 300
+	maxTransactItems    = 100
+)
+
+// Config describe la tabla de outbox. La tabla usa "id" como clave y el índice StatusIndex
+// tiene "status" como partition key y "next_attempt_at" (numérico) como sort key.
+// PollInterval, RetryBackoff, MaxBackoff y Retention se expresan en segundos; con Retention los
+// registros enviados guardan "expires_at" para que el TTL de DynamoDB los elimine.
+// Tras un fallo el registro se reintenta luego de RetryBackoff * 2^(intentos-1), hasta MaxBackoff.
+type Config struct {
+	Table        string `json:"table" yaml:"table"`
+	StatusIndex  string `json:"status_index" yaml:"status_index"`
+	BatchSize    int32  `json:"batch_size" yaml:"batch_size"`
+	PollInterval int    `json:"poll_interval" yaml:"poll_interval"`
+	MaxAttempts  int    `json:"max_attempts" yaml:"max_attempts"`
+	RetryBackoff int    `json:"retry_backoff" yaml:"retry_backoff"`
+	MaxBackoff   int    `json:"max_backoff" yaml:"max_backoff"`
+	Retention    int    `json:"retention" yaml:"retention"`
+}
+
+type Service interface {
+	Accept(ctx context.Context, itm map[string]interface{}, table string, events ...Event) error
+}
+
+// Event es un mensaje a publicar luego de guardar el item. Destination es el alias o ARN del
+// tópico SNS (o la URL de la cola cuando el relay usa SQS). Payload se serializa a JSON salvo
+// que sea string o []byte.
+type Event struct {
+	Destination string
+	EventType   string
+	Payload     interface{}
+	Attributes  map[string]string
+	GroupID     string
+}
+
+// Record es el registro persistido en la tabla de outbox. NextAttemptAt (unix en milisegundos)
+// indica desde cuándo se puede enviar un registro pendiente; se borra al enviarlo o descartarlo
+// para que deje de figurar en el índice.
+type Record struct {
+	ID            string            `dynamodbav:"id"`
+	Destination   string            `dynamodbav:"destination"`
+	EventType     string            `dynamodbav:"event_type,omitempty"`
+	Payload       string            `dynamodbav:"payload"`
+	Attributes    map[string]string `dynamodbav:"attributes,omitempty"`
+	GroupID       string            `dynamodbav:"group_id,omitempty"`
+	Status        string            `dynamodbav:"status"`
+	CreatedAt     string            `dynamodbav:"created_at"`
+	SentAt        string            `dynamodbav:"sent_at,omitempty"`
+	Attempts      int               `dynamodbav:"attempts"`
+	LastError     string            `dynamodbav:"last_error,omitempty"`
+	NextAttemptAt int64             `dynamodbav:"next_attempt_at,omitempty"`
+	ExpiresAt     int64             `dynamodbav:"expires_at,omitempty"`
+}
+
+// Dispatcher publica un registro pendiente. Las implementaciones deben ser idempotentes
+// respecto de Record.ID: el relay garantiza entrega al menos una vez.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, r Record) error
+}
+
+// DispatcherFunc adapta una función a Dispatcher.
+type DispatcherFunc func(ctx context.Context, r Record) error
+
+type Dependencies struct {
+	Client *dynamo.Dynamo
+	Log    log.Service
+}
+
+type RelayDependencies struct {
+	Client     *dynamo.Dynamo
+	Log        log.Service
+	Dispatcher Dispatcher
+}
+
+type Relay struct {
+	client     dynamo.Service
+	log        log.Service
+	dispatcher Dispatcher
+	cfg        Config
+}
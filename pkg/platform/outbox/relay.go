@@ -0,0 +1,184 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/internal/messaging"
+)
+
+func NewRelay(d RelayDependencies, cfg Config) *Relay {
+	setDefaultConfig(&cfg)
+	return &Relay{
+		client:     d.Client.Cliente,
+		log:        d.Log,
+		dispatcher: d.Dispatcher,
+		cfg:        cfg,
+	}
+}
+
+func (f DispatcherFunc) Dispatch(ctx context.Context, r Record) error {
+	return f(ctx, r)
+}
+
+// Run publica los registros pendientes hasta que se cancele ctx. Si se publicó un lote completo
+// vuelve a consultar sin esperar; si no (lote incompleto o con fallos), espera PollInterval.
+func (r *Relay) Run(ctx context.Context) error {
+	interval := time.Duration(r.cfg.PollInterval) * time.Second
+	for {
+		n, err := r.RelayOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.log.Error(ctx, err, "error publicando registros de outbox", map[string]interface{}{"table": r.cfg.Table})
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil && n == int(r.cfg.BatchSize) {
+			continue
+		}
+		if !messaging.Sleep(ctx, interval) {
+			return nil
+		}
+	}
+}
+
+// RelayOnce publica un lote de registros pendientes en orden de next_attempt_at (el de creación
+// para los nuevos), omitiendo los que esperan su próximo reintento, y devuelve cuántos publicó.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	records, err := r.pending(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, record := range records {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		ok, err := r.relay(ctx, record)
+		if err != nil {
+			return sent, err
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// pending lee hasta BatchSize registros listos para enviar. next_attempt_at es la sort key del
+// índice, así que los que esperan su reintento quedan fuera del rango y no se leen.
+func (r *Relay) pending(ctx context.Context) ([]Record, error) {
+	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(r.cfg.Table),
+		IndexName:              aws.String(r.cfg.StatusIndex),
+		KeyConditionExpression: aws.String("#status = :pending AND #next <= :now"),
+		ExpressionAttributeNames: map[string]string{
+			"#status": "status",
+			"#next":   "next_attempt_at",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pending": &types.AttributeValueMemberS{Value: StatusPending},
+			":now":     &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().UnixMilli(), 10)},
+		},
+		ScanIndexForward: aws.Bool(true),
+		Limit:            aws.Int32(r.cfg.BatchSize),
+	})
+	if err != nil {
+		return nil, r.log.WrapError(err, "error consultando registros pendientes de outbox")
+	}
+	var records []Record
+	if err := attributevalue.UnmarshalListOfMaps(out.Items, &records); err != nil {
+		return nil, r.log.WrapError(err, "error deserializando registros de outbox")
+	}
+	return records, nil
+}
+
+// relay publica el registro y devuelve si se envió; un fallo del dispatcher no es un error
+// del relay: el registro queda pendiente con su próximo reintento programado.
+func (r *Relay) relay(ctx context.Context, record Record) (bool, error) {
+	fields := map[string]interface{}{"outbox_id": record.ID, "destination": record.Destination}
+	if err := r.dispatcher.Dispatch(ctx, record); err != nil {
+		record.Attempts++
+		record.LastError = err.Error()
+		if record.Attempts >= r.cfg.MaxAttempts {
+			record.Status = StatusFailed
+			record.NextAttemptAt = 0
+			r.log.Error(ctx, err, "registro de outbox descartado tras agotar los intentos", fields)
+		} else {
+			record.NextAttemptAt = time.Now().Add(r.backoff(record.Attempts)).UnixMilli()
+			r.log.Warn(ctx, "error publicando registro de outbox", fields)
+		}
+		return false, r.update(ctx, record)
+	}
+
+	now := time.Now().UTC()
+	record.Status = StatusSent
+	record.SentAt = now.Format(time.RFC3339Nano)
+	record.LastError = ""
+	record.NextAttemptAt = 0
+	if r.cfg.Retention > 0 {
+		record.ExpiresAt = now.Add(time.Duration(r.cfg.Retention) * time.Second).Unix()
+	}
+	return true, r.update(ctx, record)
+}
+
+// backoff devuelve la espera antes del próximo intento: RetryBackoff * 2^(attempts-1), hasta MaxBackoff.
+func (r *Relay) backoff(attempts int) time.Duration {
+	limit := time.Duration(r.cfg.MaxBackoff) * time.Second
+	d := time.Duration(r.cfg.RetryBackoff) * time.Second
+	for i := 1; i < attempts && d < limit; i++ {
+		d *= 2
+	}
+	return min(d, limit)
+}
+
+// update reemplaza el registro solo si sigue pendiente, para no pisar a otro relay.
+func (r *Relay) update(ctx context.Context, record Record) error {
+	item, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		return r.log.WrapError(err, "error serializando registro de outbox")
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(r.cfg.Table),
+		Item:                      item,
+		ConditionExpression:       aws.String("#status = :pending"),
+		ExpressionAttributeNames:  map[string]string{"#status": "status"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":pending": &types.AttributeValueMemberS{Value: StatusPending}},
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return nil
+	}
+	if err != nil {
+		return r.log.WrapError(err, "error actualizando registro de outbox")
+	}
+	return nil
+}
+
+func setDefaultConfig(cfg *Config) {
+	if cfg.StatusIndex == "" {
+		cfg.StatusIndex = DefaultStatusIndex
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultMaxBackoff
+	}
+}
@@ -0,0 +1,218 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/dynamo/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/fake"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/sns"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+	logmock "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+func pendingItems(t *testing.T, records ...Record) []map[string]types.AttributeValue {
+	items := make([]map[string]types.AttributeValue, 0, len(records))
+	for _, r := range records {
+		r.Status = StatusPending
+		item, err := attributevalue.MarshalMap(r)
+		assert.NoError(t, err)
+		items = append(items, item)
+	}
+	return items
+}
+
+func putRecord(t *testing.T, args mock.Arguments) Record {
+	var r Record
+	assert.NoError(t, attributevalue.UnmarshalMap(args.Get(1).(*dynamodb.PutItemInput).Item, &r))
+	return r
+}
+
+func TestRelayOnceMarksSent(t *testing.T) {
+	cli := cl.NewService(t)
+	l := logmock.NewService(t)
+
+	cli.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return aws.ToString(in.IndexName) == DefaultStatusIndex && aws.ToInt32(in.Limit) == DefaultBatchSize
+	})).Return(&dynamodb.QueryOutput{Items: pendingItems(t, Record{ID: "1", Destination: "transfers", Payload: "a"})}, nil)
+
+	var saved Record
+	cli.On("PutItem", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { saved = putRecord(t, args) }).
+		Return(&dynamodb.PutItemOutput{}, nil)
+
+	var dispatched []string
+	relay := NewRelay(RelayDependencies{
+		Client: &dynamo.Dynamo{Cliente: cli},
+		Log:    l,
+		Dispatcher: DispatcherFunc(func(_ context.Context, r Record) error {
+			dispatched = append(dispatched, r.ID)
+			return nil
+		}),
+	}, Config{Table: "outbox", Retention: 3600})
+
+	n, err := relay.RelayOnce(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"1"}, dispatched)
+	assert.Equal(t, StatusSent, saved.Status)
+	assert.NotEmpty(t, saved.SentAt)
+	assert.NotZero(t, saved.ExpiresAt)
+	assert.Zero(t, saved.NextAttemptAt)
+}
+
+func TestRelayOnceDispatchErrorKeepsPendingUntilMaxAttempts(t *testing.T) {
+	cli := cl.NewService(t)
+	l := logmock.NewService(t)
+
+	cli.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{Items: pendingItems(t,
+		Record{ID: "1", Destination: "transfers", Payload: "a"},
+		Record{ID: "2", Destination: "transfers", Payload: "b", Attempts: 2},
+	)}, nil)
+
+	saved := map[string]Record{}
+	cli.On("PutItem", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { r := putRecord(t, args); saved[r.ID] = r }).
+		Return(&dynamodb.PutItemOutput{}, nil)
+	l.On("Warn", mock.Anything, mock.Anything, mock.Anything).Return()
+	l.On("Error", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	relay := NewRelay(RelayDependencies{
+		Client: &dynamo.Dynamo{Cliente: cli},
+		Log:    l,
+		Dispatcher: DispatcherFunc(func(context.Context, Record) error {
+			return errors.New("throttled")
+		}),
+	}, Config{Table: "outbox", MaxAttempts: 3})
+
+	n, err := relay.RelayOnce(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, StatusPending, saved["1"].Status)
+	assert.Equal(t, 1, saved["1"].Attempts)
+	assert.Equal(t, "throttled", saved["1"].LastError)
+	assert.Greater(t, saved["1"].NextAttemptAt, time.Now().UnixMilli())
+	assert.Equal(t, StatusFailed, saved["2"].Status)
+	assert.Zero(t, saved["2"].NextAttemptAt)
+}
+
+func TestRunWaitsWhenBatchFails(t *testing.T) {
+	cli := cl.NewService(t)
+	l := logmock.NewService(t)
+
+	queries := 0
+	cli.On("Query", mock.Anything, mock.MatchedBy(func(in *dynamodb.QueryInput) bool {
+		return aws.ToString(in.KeyConditionExpression) == "#status = :pending AND #next <= :now"
+	})).Run(func(mock.Arguments) { queries++ }).Return(&dynamodb.QueryOutput{
+		Items:            pendingItems(t, Record{ID: "1", Destination: "transfers"}, Record{ID: "2", Destination: "transfers"}),
+		LastEvaluatedKey: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "2"}},
+	}, nil)
+	cli.On("PutItem", mock.Anything, mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+	l.On("Warn", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	dispatched := 0
+	relay := NewRelay(RelayDependencies{
+		Client: &dynamo.Dynamo{Cliente: cli},
+		Log:    l,
+		Dispatcher: DispatcherFunc(func(context.Context, Record) error {
+			dispatched++
+			return errors.New("throttled")
+		}),
+	}, Config{Table: "outbox", BatchSize: 2})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, relay.Run(ctx))
+
+	assert.Equal(t, 1, queries)
+	assert.Equal(t, 2, dispatched)
+}
+
+func TestRelayOnceQueriesOnlyDueRecords(t *testing.T) {
+	cli := cl.NewService(t)
+	l := logmock.NewService(t)
+
+	var input *dynamodb.QueryInput
+	cli.On("Query", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { input = args.Get(1).(*dynamodb.QueryInput) }).
+		Return(&dynamodb.QueryOutput{}, nil).Once()
+
+	relay := NewRelay(RelayDependencies{
+		Client:     &dynamo.Dynamo{Cliente: cli},
+		Log:        l,
+		Dispatcher: DispatcherFunc(func(context.Context, Record) error { return nil }),
+	}, Config{Table: "outbox", BatchSize: 2})
+
+	before := time.Now().UnixMilli()
+	n, err := relay.RelayOnce(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Equal(t, "#status = :pending AND #next <= :now", aws.ToString(input.KeyConditionExpression))
+	assert.Nil(t, input.FilterExpression)
+	assert.Equal(t, int32(2), aws.ToInt32(input.Limit))
+	now, err := strconv.ParseInt(input.ExpressionAttributeValues[":now"].(*types.AttributeValueMemberN).Value, 10, 64)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, now, before)
+}
+
+func TestBackoffGrowsUpToMax(t *testing.T) {
+	relay := NewRelay(RelayDependencies{Client: &dynamo.Dynamo{}}, Config{Table: "outbox", RetryBackoff: 2, MaxBackoff: 60})
+
+	assert.Equal(t, 2*time.Second, relay.backoff(1))
+	assert.Equal(t, 16*time.Second, relay.backoff(4))
+	assert.Equal(t, 60*time.Second, relay.backoff(10))
+}
+
+func TestRelayOnceIgnoresRecordAlreadyHandled(t *testing.T) {
+	cli := cl.NewService(t)
+	l := logmock.NewService(t)
+
+	cli.On("Query", mock.Anything, mock.Anything).
+		Return(&dynamodb.QueryOutput{Items: pendingItems(t, Record{ID: "1", Destination: "transfers"})}, nil)
+	cli.On("PutItem", mock.Anything, mock.Anything).
+		Return(nil, &types.ConditionalCheckFailedException{Message: aws.String("conditional request failed")})
+
+	relay := NewRelay(RelayDependencies{
+		Client:     &dynamo.Dynamo{Cliente: cli},
+		Log:        l,
+		Dispatcher: DispatcherFunc(func(context.Context, Record) error { return nil }),
+	}, Config{Table: "outbox"})
+
+	n, err := relay.RelayOnce(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestSNSDispatcherPublishesRecord(t *testing.T) {
+	broker := fake.NewBroker()
+	topicArn := broker.CreateTopic("transfers")
+	url := broker.CreateQueue("transfers-worker")
+	assert.NoError(t, broker.Subscribe(topicArn, url, fake.SubscriptionOptions{RawDelivery: true}))
+
+	publisher := sns.NewPublisher(sns.Dependencies{Client: broker.SNS(), Log: log.NewService(log.Config{}, logrus.New())},
+		sns.Config{Topics: map[string]string{"transfers": topicArn}})
+	err := SNSDispatcher(publisher).Dispatch(context.TODO(), Record{
+		ID:          "1",
+		Destination: "transfers",
+		EventType:   "transfer_created",
+		Payload:     `{"id":"t-1"}`,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`{"id":"t-1"}`}, broker.Bodies(url))
+}
@@ -0,0 +1,106 @@
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	"github.com/uala-challenge/simple-toolkit/pkg/internal/messaging"
+	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
+)
+
+type service struct {
+	client *dynamo.Dynamo
+	log    log.Service
+	cfg    Config
+}
+
+var _ Service = (*service)(nil)
+
+func NewService(d Dependencies, cfg Config) *service {
+	return &service{
+		client: d.Client,
+		log:    d.Log,
+		cfg:    cfg,
+	}
+}
+
+// Accept guarda itm en table y un registro de outbox por evento en una única TransactWriteItems.
+func (s *service) Accept(ctx context.Context, itm map[string]interface{}, table string, events ...Event) error {
+	if len(events)+1 > maxTransactItems {
+		return fmt.Errorf("outbox: a transaction supports up to %d events", maxTransactItems-1)
+	}
+	item, err := attributevalue.MarshalMap(itm)
+	if err != nil {
+		return s.log.WrapError(err, "Error serializando item")
+	}
+
+	writes := make([]types.TransactWriteItem, 0, len(events)+1)
+	writes = append(writes, types.TransactWriteItem{
+		Put: &types.Put{TableName: aws.String(table), Item: item},
+	})
+	now := time.Now().UTC()
+	for _, e := range events {
+		record, err := newRecord(e, now)
+		if err != nil {
+			return s.log.WrapError(err, "Error serializando evento de outbox")
+		}
+		av, err := attributevalue.MarshalMap(record)
+		if err != nil {
+			return s.log.WrapError(err, "Error serializando evento de outbox")
+		}
+		writes = append(writes, types.TransactWriteItem{
+			Put: &types.Put{
+				TableName:           aws.String(s.cfg.Table),
+				Item:                av,
+				ConditionExpression: aws.String("attribute_not_exists(id)"),
+			},
+		})
+	}
+
+	_, err = s.client.Cliente.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: writes})
+	if err != nil {
+		return s.log.WrapError(err, "Error al guardar el item y su outbox")
+	}
+	return nil
+}
+
+func newRecord(e Event, createdAt time.Time) (Record, error) {
+	if e.Destination == "" {
+		return Record{}, fmt.Errorf("outbox event requires a destination")
+	}
+	payload, err := messaging.MarshalPayload(e.Payload)
+	if err != nil {
+		return Record{}, err
+	}
+	id, err := newID()
+	if err != nil {
+		return Record{}, err
+	}
+	return Record{
+		ID:            id,
+		Destination:   e.Destination,
+		EventType:     e.EventType,
+		Payload:       payload,
+		Attributes:    e.Attributes,
+		GroupID:       e.GroupID,
+		Status:        StatusPending,
+		CreatedAt:     createdAt.Format(time.RFC3339Nano),
+		NextAttemptAt: createdAt.UnixMilli(),
+	}, nil
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,75 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/uala-challenge/simple-toolkit/pkg/client/dynamo"
+	cl "github.com/uala-challenge/simple-toolkit/pkg/client/dynamo/mock"
+	log "github.com/uala-challenge/simple-toolkit/pkg/utilities/log/mock"
+)
+
+func TestAcceptWritesItemAndOutboxInOneTransaction(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	var input *dynamodb.TransactWriteItemsInput
+	cli.On("TransactWriteItems", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { input = args.Get(1).(*dynamodb.TransactWriteItemsInput) }).
+		Return(&dynamodb.TransactWriteItemsOutput{}, nil)
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, Config{Table: "outbox"})
+	err := cliente.Accept(context.TODO(), map[string]interface{}{"PK": "account:1", "balance": 100}, "accounts",
+		Event{Destination: "transfers", EventType: "transfer_created", Payload: map[string]int{"amount": 100}})
+
+	assert.NoError(t, err)
+	assert.Len(t, input.TransactItems, 2)
+	assert.Equal(t, "accounts", aws.ToString(input.TransactItems[0].Put.TableName))
+
+	outbox := input.TransactItems[1].Put
+	assert.Equal(t, "outbox", aws.ToString(outbox.TableName))
+	assert.Equal(t, "attribute_not_exists(id)", aws.ToString(outbox.ConditionExpression))
+	var record Record
+	assert.NoError(t, attributevalue.UnmarshalMap(outbox.Item, &record))
+	assert.NotEmpty(t, record.ID)
+	assert.Equal(t, StatusPending, record.Status)
+	assert.Equal(t, "transfers", record.Destination)
+	assert.Equal(t, `{"amount":100}`, record.Payload)
+	assert.NotEmpty(t, record.CreatedAt)
+	assert.NotZero(t, record.NextAttemptAt)
+}
+
+func TestAcceptTransactionError(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	cli.On("TransactWriteItems", mock.Anything, mock.Anything).Return(nil, errors.New("TransactionCanceledException"))
+	l.On("WrapError", mock.Anything, "Error al guardar el item y su outbox").
+		Return(errors.New("Error al guardar el item y su outbox"))
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, Config{Table: "outbox"})
+	err := cliente.Accept(context.TODO(), map[string]interface{}{"PK": "123"}, "accounts",
+		Event{Destination: "transfers", Payload: "hola"})
+
+	assert.Error(t, err)
+}
+
+func TestAcceptRequiresDestination(t *testing.T) {
+	cli := cl.NewService(t)
+	l := log.NewService(t)
+
+	l.On("WrapError", mock.Anything, "Error serializando evento de outbox").
+		Return(errors.New("Error serializando evento de outbox"))
+
+	cliente := NewService(Dependencies{Client: &dynamo.Dynamo{Cliente: cli}, Log: l}, Config{Table: "outbox"})
+	err := cliente.Accept(context.TODO(), map[string]interface{}{"PK": "123"}, "accounts", Event{Payload: "hola"})
+
+	assert.Error(t, err)
+	cli.AssertNotCalled(t, "TransactWriteItems", mock.Anything, mock.Anything)
+}
@@ -15,6 +15,7 @@ type Engine struct {
 	Log                log.Service
 	SQSClient          *sqs.Sqs
 	SNSClient          *sns.Sns
+	SNSPublisher       *sns.Publisher
 	DynamoDBClient     *dynamo.Dynamo
 	RedisClient        *redis.Client
 	RestClients        map[string]rest.Service
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/uala-challenge/simple-toolkit/pkg/client/rest"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/uala-challenge/simple-toolkit/pkg/client/sqs"
 	"github.com/uala-challenge/simple-toolkit/pkg/config/viper"
+	"github.com/uala-challenge/simple-toolkit/pkg/platform/db/repository"
 	"github.com/uala-challenge/simple-toolkit/pkg/simplify/simple_router"
 	"github.com/uala-challenge/simple-toolkit/pkg/utilities/log"
 )
@@ -37,19 +39,22 @@ func NewApp() *Engine {
 		tracer.Fatal(err)
 	}
 	awsCfg := loadAWSConfig(c.Aws, tracer)
-	return &Engine{
+	redisClient := createRedisService(c.Redis, tracer)
+	engine := &Engine{
 		App:                simple_router.NewService(c.Router),
 		SQSClient:          createSQSService(awsCfg, c.SQS, tracer),
 		SNSClient:          createSNSClient(awsCfg, c.SNS, tracer),
 		DynamoDBClient:     createDynamoClient(awsCfg, c.Dynamo, tracer),
-		RedisClient:        createRedisService(c.Redis, tracer),
+		RedisClient:        redisClient,
 		RepositoriesConfig: c.Repositories,
 		UsesCasesConfig:    c.Cases,
 		HandlerConfig:      c.Endpoints,
 		BatchConfig:        c.Processors,
-		RestClients:        createHttpClient(c.Rest, tracer),
+		RestClients:        createHttpClient(c.Rest, redisClient, awsCfg, tracer),
 		Log:                configLogLevel(c.Log, tracer),
 	}
+	engine.SNSPublisher = createSNSPublisher(engine.SNSClient, c.SNS, engine.Log)
+	return engine
 }
 
 func configLogLevel(c log.Config, l *logrus.Logger) log.Service {
@@ -72,6 +77,13 @@ func createSNSClient(acf aws.Config, cfg *sns.Config, l *logrus.Logger) *sns.Sns
 	return sns.NewClient(acf, cfg.Endpoint, l)
 }
 
+func createSNSPublisher(client *sns.Sns, cfg *sns.Config, l log.Service) *sns.Publisher {
+	if client == nil || cfg == nil {
+		return nil
+	}
+	return sns.NewPublisher(sns.Dependencies{Client: client, Log: l}, *cfg)
+}
+
 func createDynamoClient(acf aws.Config, cfg *dynamo.Config, l *logrus.Logger) *dynamo.Dynamo {
 	if cfg == nil {
 		return nil
@@ -91,11 +103,18 @@ func createRedisService(cfg *rd.Config, l *logrus.Logger) *redis.Client {
 	return client
 }
 
-func createHttpClient(c []map[string]rest.Config, l *logrus.Logger) map[string]rest.Service {
+func createHttpClient(c []map[string]rest.Config, rc *redis.Client, acf aws.Config, l *logrus.Logger) map[string]rest.Service {
 	httpClients := make(map[string]rest.Service)
 	for _, v := range c {
 		for k, v := range v {
-			httpClients[k] = rest.NewClient(v, l)
+			var opts []rest.Option
+			if v.CacheBackend == rest.CacheBackendRedis && rc != nil {
+				opts = append(opts, rest.WithCacheStore(rest.NewRedisCacheStore(rc)))
+			}
+			if strings.EqualFold(v.Auth.Type, rest.AuthSigV4) {
+				opts = append(opts, rest.WithAWSConfig(acf))
+			}
+			httpClients[k] = rest.NewClient(v, l, opts...)
 		}
 	}
 	return httpClients
@@ -125,3 +144,29 @@ func GetConfig[O any](c map[string]interface{}) O {
 	}
 	return h
 }
+
+// NewConsumer crea un consumidor SQS con la configuración declarada en processors.<name>.
+func (e *Engine) NewConsumer(name string, h sqs.Handler) (*sqs.Consumer, error) {
+	if e.SQSClient == nil {
+		return nil, fmt.Errorf("sqs client not configured")
+	}
+	c, ok := e.BatchConfig[name].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("processor %s not configured", name)
+	}
+	cfg := GetConfig[sqs.ConsumerConfig](c)
+	return sqs.NewConsumer(sqs.Dependencies{Client: e.SQSClient, Log: e.Log}, cfg, h), nil
+}
+
+// NewRepository crea un repositorio tipado con la configuración declarada en repositories.<name>.
+func NewRepository[T any](e *Engine, name string) (*repository.Repository[T], error) {
+	if e.DynamoDBClient == nil {
+		return nil, fmt.Errorf("dynamo client not configured")
+	}
+	c, ok := e.RepositoriesConfig[name].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("repository %s not configured", name)
+	}
+	cfg := GetConfig[repository.Config](c)
+	return repository.NewRepository[T](repository.Dependencies{Client: e.DynamoDBClient, Log: e.Log}, cfg), nil
+}